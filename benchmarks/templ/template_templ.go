@@ -33,7 +33,11 @@ func Render(p Person) templ.Component {
 			return err
 		}
 		// StringExpression
-		var var_2 string = p.Name
+		var var_2 string
+		var_2, err = templ.ToString(p.Name)
+		if err != nil {
+			return err
+		}
 		_, err = templBuffer.WriteString(templ.EscapeString(var_2))
 		if err != nil {
 			return err
@@ -115,7 +119,11 @@ func Render(p Person) templ.Component {
 			return err
 		}
 		// StringExpression
-		var var_5 string = p.Email
+		var var_5 string
+		var_5, err = templ.ToString(p.Email)
+		if err != nil {
+			return err
+		}
 		_, err = templBuffer.WriteString(templ.EscapeString(var_5))
 		if err != nil {
 			return err
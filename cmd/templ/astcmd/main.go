@@ -0,0 +1,155 @@
+package astcmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+
+	"github.com/a-h/templ/generator"
+	parser "github.com/a-h/templ/parser/v2"
+)
+
+type Arguments struct {
+	// FileName of the templ file to print the parse tree of. If it's empty, stdin is read.
+	FileName string
+	// SourceMap adds the source map of the generated Go code to the output.
+	SourceMap bool
+}
+
+// Run writes the parse tree of a templ file to stdout as JSON.
+func Run(args Arguments) (err error) {
+	var src []byte
+	if args.FileName != "" {
+		src, err = os.ReadFile(args.FileName)
+	} else {
+		src, err = io.ReadAll(os.Stdin)
+	}
+	if err != nil {
+		return err
+	}
+	return Write(os.Stdout, args.FileName, string(src), args.SourceMap)
+}
+
+// Output is the JSON document written by the ast command.
+type Output struct {
+	FileName     string            `json:",omitempty"`
+	TemplateFile any               `json:",omitempty"`
+	SourceMap    *parser.SourceMap `json:",omitempty"`
+	Errors       []parser.Error    `json:",omitempty"`
+}
+
+// Write parses the templ source, and writes its parse tree to w as JSON. Each node and
+// attribute has a "Type" field that contains the name of its Go type, e.g. "Element".
+// If the parser recovered from errors, the valid parts of the file are written, along
+// with the errors, and an error is returned.
+func Write(w io.Writer, fileName, src string, sourceMap bool) (err error) {
+	tf, parseErr := parser.ParseString(src)
+	op := Output{
+		FileName: fileName,
+	}
+	if errs, isRecovered := parseErr.(parser.Errors); isRecovered {
+		op.Errors = errs
+	} else if parseErr != nil {
+		return fmt.Errorf("parsing error: %w", parseErr)
+	}
+	op.TemplateFile = toJSON(reflect.ValueOf(tf))
+	if sourceMap && parseErr == nil {
+		op.SourceMap, err = generator.Generate(tf, io.Discard, generator.WithFileName(fileName))
+		if err != nil {
+			return fmt.Errorf("generation error: %w", err)
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err = enc.Encode(op); err != nil {
+		return err
+	}
+	if parseErr != nil {
+		return fmt.Errorf("parsing error: %w", parseErr)
+	}
+	return nil
+}
+
+// Types that are written without a "Type" field, because they're not nodes.
+var valueTypes = map[reflect.Type]bool{
+	reflect.TypeOf(parser.Expression{}): true,
+	reflect.TypeOf(parser.Range{}):      true,
+	reflect.TypeOf(parser.Position{}):   true,
+}
+
+// toJSON converts a parse tree value to a value that can be marshalled to JSON, adding
+// a "Type" discriminator to each struct, so that the implementations of the Node,
+// Attribute, TemplateFileNode and CSSProperty interfaces can be told apart.
+func toJSON(v reflect.Value) any {
+	switch v.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		return toJSON(v.Elem())
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		items := make([]any, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			items[i] = toJSON(v.Index(i))
+		}
+		return items
+	case reflect.Struct:
+		if valueTypes[v.Type()] {
+			return v.Interface()
+		}
+		o := object{{Name: "Type", Value: v.Type().Name()}}
+		for i := 0; i < v.NumField(); i++ {
+			if f := v.Type().Field(i); f.IsExported() {
+				o = append(o, field{Name: f.Name, Value: toJSON(v.Field(i))})
+			}
+		}
+		return o
+	}
+	return v.Interface()
+}
+
+type field struct {
+	Name  string
+	Value any
+}
+
+// object is a JSON object that keeps the order of its fields.
+type object []field
+
+func (o object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := encode(&buf, f.Name); err != nil {
+			return nil, err
+		}
+		buf.WriteByte(':')
+		if err := encode(&buf, f.Value); err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// encode writes v as JSON, without escaping the HTML in templates.
+func encode(buf *bytes.Buffer, v any) error {
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	// Remove the newline added by Encode.
+	buf.Truncate(buf.Len() - 1)
+	return nil
+}
@@ -0,0 +1,93 @@
+package astcmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWrite(t *testing.T) {
+	src := `package main
+
+templ Hello(name string) {
+	<div class="a">{ name }</div>
+}
+`
+	buf := new(bytes.Buffer)
+	if err := Write(buf, "hello.templ", src, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var actual struct {
+		FileName     string
+		TemplateFile struct {
+			Type  string
+			Nodes []struct {
+				Type     string
+				Children []struct {
+					Type       string
+					Name       string
+					Attributes []map[string]any
+				}
+			}
+		}
+		SourceMap map[string]any
+	}
+	if err := json.Unmarshal(buf.Bytes(), &actual); err != nil {
+		t.Fatalf("failed to unmarshal output: %v", err)
+	}
+	if actual.FileName != "hello.templ" {
+		t.Errorf("expected the file name to be written, got %q", actual.FileName)
+	}
+	if actual.TemplateFile.Type != "TemplateFile" || actual.TemplateFile.Nodes[0].Type != "HTMLTemplate" {
+		t.Errorf("expected TemplateFile and HTMLTemplate types, got %+v", actual.TemplateFile)
+	}
+	div := actual.TemplateFile.Nodes[0].Children[1]
+	if div.Type != "Element" || div.Name != "div" {
+		t.Errorf("expected a div element, got %+v", div)
+	}
+	attr := div.Attributes[0]
+	if diff := cmp.Diff("ConstantAttribute", attr["Type"]); diff != "" {
+		t.Error(diff)
+	}
+	if _, hasRange := attr["Range"]; !hasRange {
+		t.Error("expected the attribute to have a range")
+	}
+	if actual.SourceMap == nil {
+		t.Error("expected the source map to be written")
+	}
+}
+
+func TestWriteIncludesRecoveredErrors(t *testing.T) {
+	src := `package main
+
+templ Hello() {
+	<div><a></b>
+	</div>
+}
+`
+	buf := new(bytes.Buffer)
+	if err := Write(buf, "hello.templ", src, false); err == nil {
+		t.Error("expected an error")
+	}
+	var actual struct {
+		TemplateFile map[string]any
+		Errors       []struct {
+			Msg string
+		}
+	}
+	if err := json.Unmarshal(buf.Bytes(), &actual); err != nil {
+		t.Fatalf("failed to unmarshal output: %v", err)
+	}
+	if actual.TemplateFile == nil {
+		t.Error("expected the partial template file to be written")
+	}
+	expected := []struct{ Msg string }{{Msg: "<a>: mismatched end tag, expected '</a>', got '</b>'"}}
+	if diff := cmp.Diff(expected, actual.Errors); diff != "" {
+		t.Error(diff)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte(`"Msg": "<a>`)) {
+		t.Error("expected HTML not to be escaped")
+	}
+}
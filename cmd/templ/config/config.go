@@ -0,0 +1,89 @@
+// Package config loads project settings for templ from a .templ.json file.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/a-h/templ/parser/v2"
+)
+
+// FileName of the project config file. It's found by searching the directory of a templ
+// file, and its parent directories.
+const FileName = ".templ.json"
+
+// Config for a project.
+type Config struct {
+	// Format options used by templ fmt and the LSP.
+	Format Format `json:"format"`
+	// Lint options used by templ lint.
+	Lint Lint `json:"lint"`
+}
+
+// Lint options.
+type Lint struct {
+	// Rules sets the severity of lint rules by name, to "error", "warning" or "off".
+	Rules map[string]string `json:"rules"`
+}
+
+// Format options.
+type Format struct {
+	// UseSpaces indents with spaces instead of tabs.
+	UseSpaces bool `json:"useSpaces"`
+	// TabWidth is the width of a tab, and the number of spaces used for indentation if
+	// UseSpaces is set.
+	TabWidth int `json:"tabWidth"`
+	// MaxLineWidth is the width at which element attributes are written one per line, and
+	// text is wrapped if WrapText is set. Zero disables wrapping.
+	MaxLineWidth int `json:"maxLineWidth"`
+	// MaxAttributesPerLine is the number of attributes an element can have before they're
+	// written one per line. Zero disables the limit.
+	MaxAttributesPerLine int `json:"maxAttributesPerLine"`
+	// WrapText wraps text content at MaxLineWidth.
+	WrapText bool `json:"wrapText"`
+}
+
+// Options returns the parser options for formatting templates.
+func (f Format) Options() parser.FormatOptions {
+	opts := parser.DefaultFormatOptions()
+	opts.UseSpaces = f.UseSpaces
+	if f.TabWidth > 0 {
+		opts.TabWidth = f.TabWidth
+	}
+	opts.MaxLineWidth = f.MaxLineWidth
+	opts.MaxAttributesPerLine = f.MaxAttributesPerLine
+	opts.WrapText = f.WrapText
+	return opts
+}
+
+// Load the config that applies to files in the directory, by searching the directory and its
+// parents for a config file. If there isn't one, the default config is returned, and the
+// fileName is empty.
+func Load(dir string) (c Config, fileName string, err error) {
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return c, "", err
+	}
+	for {
+		fileName = filepath.Join(dir, FileName)
+		data, err := os.ReadFile(fileName)
+		if err == nil {
+			if err = json.Unmarshal(data, &c); err != nil {
+				return c, fileName, fmt.Errorf("%s: invalid config: %w", fileName, err)
+			}
+			return c, fileName, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return c, fileName, err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return c, "", nil
+		}
+		dir = parent
+	}
+}
@@ -6,18 +6,35 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"time"
 
+	"github.com/a-h/templ/cmd/templ/config"
+	"github.com/a-h/templ/cmd/templ/output"
 	"github.com/a-h/templ/cmd/templ/processor"
+	"github.com/a-h/templ/generator/imports"
 	parser "github.com/a-h/templ/parser/v2"
 	"github.com/natefinch/atomic"
 )
 
 const workerCount = 4
 
-func Run(args []string) (err error) {
-	if len(args) > 0 {
-		return formatDir(args[0])
+type Arguments struct {
+	// Paths of files or directories to format. If there are none, stdin is formatted and
+	// written to stdout.
+	Paths []string
+	// Check lists files that aren't formatted and returns an error if there are any,
+	// without changing them.
+	Check bool
+	// Diff writes a unified diff for each file that isn't formatted, without changing them.
+	Diff bool
+	// LogFormat is the format of progress and errors written to stdout, text by default.
+	LogFormat output.Format
+}
+
+func Run(args Arguments) (err error) {
+	if len(args.Paths) > 0 {
+		return formatPaths(output.New(os.Stdout, args.LogFormat), args)
 	}
 	return formatStdin()
 }
@@ -32,41 +49,111 @@ func formatStdin() (err error) {
 	if err != nil {
 		return fmt.Errorf("parsing error: %w", err)
 	}
-	err = t.Write(os.Stdout)
+	c, _, err := config.Load(".")
+	if err != nil {
+		return err
+	}
+	t = imports.GroupTemplateFile(t)
+	err = t.WriteWithOptions(os.Stdout, c.Format.Options())
 	if err != nil {
 		return fmt.Errorf("formatting error: %w", err)
 	}
 	return nil
 }
 
-func formatDir(dir string) (err error) {
+// formatPaths formats all templates in the paths, which can be files or directories. Errors
+// are written to the log as they happen, and the returned error is marked as already reported.
+func formatPaths(log *output.Writer, args Arguments) (err error) {
 	start := time.Now()
+	readOnly := args.Check || args.Diff
+	f := func(fileName string) error {
+		return format(fileName, readOnly, args.Diff)
+	}
 	results := make(chan processor.Result)
-	go processor.Process(".", format, workerCount, results)
-	var successCount, errorCount int
+	go func() {
+		defer close(results)
+		for _, path := range args.Paths {
+			pathResults := make(chan processor.Result)
+			go processor.Process(path, f, workerCount, pathResults)
+			for r := range pathResults {
+				results <- r
+			}
+		}
+	}()
+	var successCount, errorCount, unformattedCount int
 	for r := range results {
+		var nf notFormattedError
+		if errors.As(r.Error, &nf) {
+			log.Changed(r.FileName, output.StatusUnformatted, nf.diff)
+			unformattedCount++
+			successCount++
+			continue
+		}
+		if r.Error != nil || !readOnly {
+			log.File(r.FileName, r.Duration, r.Error)
+		}
 		if r.Error != nil {
 			err = errors.Join(err, fmt.Errorf("%s: %w", r.FileName, r.Error))
 			errorCount++
 			continue
 		}
-		fmt.Printf("%s complete in %v\n", r.FileName, r.Duration)
 		successCount++
 	}
-	fmt.Printf("Formatted %d templates with %d errors in %s\n", successCount+errorCount, errorCount, time.Since(start))
-	return
+	verb := "Formatted"
+	if readOnly {
+		verb = "Checked"
+	}
+	log.Summary(fmt.Sprintf("%s %d templates with %d errors in %s", verb, successCount+errorCount, errorCount, time.Since(start)), successCount+errorCount, errorCount, time.Since(start))
+	err = output.Reported(err)
+	if args.Check && unformattedCount > 0 {
+		err = errors.Join(err, fmt.Errorf("%d templates are not formatted", unformattedCount))
+	}
+	return err
 }
 
-func format(fileName string) (err error) {
-	t, err := parser.Parse(fileName)
+// notFormattedError is returned for files that need formatting when files aren't being
+// written, so that they can be listed.
+type notFormattedError struct {
+	diff string
+}
+
+func (e notFormattedError) Error() string {
+	return "not formatted"
+}
+
+// format the file. If readOnly is set, the file isn't written, and a notFormattedError is
+// returned if the file needs formatting.
+func format(fileName string, readOnly, diff bool) (err error) {
+	original, err := os.ReadFile(fileName)
+	if err != nil {
+		return fmt.Errorf("%s read file error: %w", fileName, err)
+	}
+	t, err := parser.ParseString(string(original))
 	if err != nil {
 		return fmt.Errorf("%s parsing error: %w", fileName, err)
 	}
+	c, _, err := config.Load(filepath.Dir(fileName))
+	if err != nil {
+		return err
+	}
+	t = imports.GroupTemplateFile(t)
 	w := new(bytes.Buffer)
-	err = t.Write(w)
+	err = t.WriteWithOptions(w, c.Format.Options())
 	if err != nil {
 		return fmt.Errorf("%s formatting error: %w", fileName, err)
 	}
+	if bytes.Equal(original, w.Bytes()) {
+		return nil
+	}
+	if readOnly {
+		nf := notFormattedError{}
+		if diff {
+			if nf.diff, err = output.UnifiedDiff(fileName, original, w.Bytes()); err != nil {
+				return fmt.Errorf("%s diff error: %w", fileName, err)
+			}
+		}
+		return nf
+	}
 	err = atomic.WriteFile(fileName, w)
 	if err != nil {
 		return fmt.Errorf("%s file write error: %w", fileName, err)
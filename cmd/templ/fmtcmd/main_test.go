@@ -0,0 +1,150 @@
+package fmtcmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/a-h/templ/cmd/templ/output"
+)
+
+const formatted = `package test
+
+templ Hello(name string) {
+	<div>{ name }</div>
+}
+
+`
+
+const unformatted = `package test
+
+templ Hello(name string) {
+<div>{name}</div>
+}
+`
+
+func TestFormatPaths(t *testing.T) {
+	tests := []struct {
+		name string
+		args Arguments
+		// files are the file names that are passed as paths, relative to the test directory.
+		// Other files are found by walking the directory.
+		files            []string
+		expectedErr      bool
+		expectedWritten  bool
+		expectedOutput   []string
+		unexpectedOutput []string
+	}{
+		{
+			name:            "unformatted files are formatted",
+			files:           []string{"."},
+			expectedWritten: true,
+		},
+		{
+			name:            "files in multiple paths are formatted",
+			files:           []string{"a", filepath.Join("b", "b.templ")},
+			expectedWritten: true,
+		},
+		{
+			name:             "-check lists unformatted files and returns an error without writing",
+			args:             Arguments{Check: true},
+			files:            []string{"a", "b"},
+			expectedErr:      true,
+			expectedOutput:   []string{filepath.Join("a", "a.templ"), filepath.Join("b", "b.templ")},
+			unexpectedOutput: []string{"formatted.templ"},
+		},
+		{
+			name:  "-d writes a unified diff without writing",
+			args:  Arguments{Diff: true},
+			files: []string{"."},
+			expectedOutput: []string{
+				"--- " + filepath.Join("a", "a.templ") + ".orig",
+				"+++ " + filepath.Join("a", "a.templ"),
+				"@@ ",
+				"-<div>{name}</div>",
+				"+\t<div>{ name }</div>",
+			},
+			unexpectedOutput: []string{"formatted.templ"},
+		},
+		{
+			name:           "-check and -d return an error and write a unified diff",
+			args:           Arguments{Check: true, Diff: true},
+			files:          []string{"."},
+			expectedErr:    true,
+			expectedOutput: []string{"+\t<div>{ name }</div>"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFile(t, filepath.Join(dir, "a", "a.templ"), unformatted)
+			writeFile(t, filepath.Join(dir, "b", "b.templ"), unformatted)
+			writeFile(t, filepath.Join(dir, "a", "formatted.templ"), formatted)
+			// Use relative paths, so that the output can be checked.
+			wd, err := os.Getwd()
+			if err != nil {
+				t.Fatalf("failed to get working directory: %v", err)
+			}
+			if err = os.Chdir(dir); err != nil {
+				t.Fatalf("failed to change directory: %v", err)
+			}
+			defer func() {
+				_ = os.Chdir(wd)
+			}()
+
+			args := tt.args
+			args.Paths = tt.files
+			var buf bytes.Buffer
+			err = formatPaths(output.New(&buf, output.FormatText), args)
+			if tt.expectedErr && err == nil {
+				t.Errorf("expected an error, got nil\n%s", buf.String())
+			}
+			if !tt.expectedErr && err != nil {
+				t.Errorf("unexpected error: %v\n%s", err, buf.String())
+			}
+			for _, s := range tt.expectedOutput {
+				if !strings.Contains(buf.String(), s) {
+					t.Errorf("expected output to contain %q, got:\n%s", s, buf.String())
+				}
+			}
+			for _, s := range tt.unexpectedOutput {
+				if strings.Contains(buf.String(), s) {
+					t.Errorf("expected output not to contain %q, got:\n%s", s, buf.String())
+				}
+			}
+			expected := unformatted
+			if tt.expectedWritten {
+				expected = formatted
+			}
+			for _, fileName := range []string{filepath.Join("a", "a.templ"), filepath.Join("b", "b.templ")} {
+				if actual := readFile(t, fileName); actual != expected {
+					t.Errorf("%s: expected:\n%s\ngot:\n%s", fileName, expected, actual)
+				}
+			}
+			if actual := readFile(t, filepath.Join("a", "formatted.templ")); actual != formatted {
+				t.Errorf("expected formatted files to be unchanged, got:\n%s", actual)
+			}
+		})
+	}
+}
+
+func writeFile(t *testing.T, fileName, contents string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(fileName, []byte(contents), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+}
+
+func readFile(t *testing.T, fileName string) string {
+	t.Helper()
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	return string(data)
+}
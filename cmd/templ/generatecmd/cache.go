@@ -0,0 +1,169 @@
+package generatecmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/a-h/templ/generator"
+)
+
+// hashCache stores the hashes of templ files and the Go code generated from them,
+// so that unchanged files don't need to be parsed and generated again.
+type hashCache struct {
+	m        sync.Mutex
+	fileName string
+	// packages caches the hash of the Go files in each directory until the cache is saved,
+	// since a directory usually contains several templates.
+	packages map[string]string
+	// Version of templ that generated the files. If the version changes, the cache is discarded.
+	Version string `json:"version"`
+	// Files maps the templ file name to the hashes.
+	Files map[string]hashCacheEntry `json:"files"`
+}
+
+type hashCacheEntry struct {
+	Input  string `json:"input"`
+	Output string `json:"output"`
+	// Package is the hash of the other Go files in the templ file's directory, since they're
+	// used to resolve imports in the generated code.
+	Package string `json:"package"`
+}
+
+// loadHashCache loads the cache from disk, or creates an empty cache if the file doesn't exist.
+func loadHashCache(fileName string) (c *hashCache, err error) {
+	c = &hashCache{
+		fileName: fileName,
+		packages: make(map[string]string),
+		Version:  generator.Version(),
+		Files:    make(map[string]hashCacheEntry),
+	}
+	data, err := os.ReadFile(fileName)
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return c, err
+	}
+	var stored hashCache
+	if err = json.Unmarshal(data, &stored); err != nil {
+		// Treat an invalid cache as empty, it will be overwritten.
+		return c, nil
+	}
+	if stored.Version != c.Version || stored.Files == nil {
+		return c, nil
+	}
+	c.Files = stored.Files
+	return c, nil
+}
+
+// UpToDate returns true if the templ file contents, the Go files in the same package, and
+// the generated Go file on disk are the same as when they were last generated.
+func (c *hashCache) UpToDate(templFileName string, input []byte, goFileName string) bool {
+	c.m.Lock()
+	entry, ok := c.Files[templFileName]
+	c.m.Unlock()
+	if !ok || entry.Input != hash(input) {
+		return false
+	}
+	if entry.Package != c.packageHash(filepath.Dir(templFileName)) {
+		return false
+	}
+	output, err := os.ReadFile(goFileName)
+	if err != nil {
+		return false
+	}
+	return entry.Output == hash(output)
+}
+
+// Set the hashes of a templ file and the Go code generated from it.
+func (c *hashCache) Set(templFileName string, input, output []byte) {
+	packageHash := c.packageHash(filepath.Dir(templFileName))
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.Files[templFileName] = hashCacheEntry{
+		Input:   hash(input),
+		Output:  hash(output),
+		Package: packageHash,
+	}
+}
+
+// packageHash returns a hash of the names and contents of the Go files in the directory,
+// excluding generated templ files, or an empty string if they can't be read.
+func (c *hashCache) packageHash(dir string) string {
+	c.m.Lock()
+	h, ok := c.packages[dir]
+	c.m.Unlock()
+	if ok {
+		return h
+	}
+	h = hashGoFiles(dir)
+	c.m.Lock()
+	c.packages[dir] = h
+	c.m.Unlock()
+	return h
+}
+
+func hashGoFiles(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	var names []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_templ.go") {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	h := sha256.New()
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return ""
+		}
+		h.Write([]byte(name))
+		h.Write([]byte{0})
+		h.Write([]byte(hash(data)))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Prune removes templ files that no longer exist from the cache.
+func (c *hashCache) Prune() {
+	c.m.Lock()
+	defer c.m.Unlock()
+	for templFileName := range c.Files {
+		if _, err := os.Stat(templFileName); errors.Is(err, fs.ErrNotExist) {
+			delete(c.Files, templFileName)
+		}
+	}
+}
+
+// Save the cache to disk. Go files may change before code is next generated, so the
+// package hashes are calculated again after saving.
+func (c *hashCache) Save() error {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.packages = make(map[string]string)
+	data, err := json.MarshalIndent(c, "", " ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.fileName, data, 0644)
+}
+
+func hash(data []byte) string {
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
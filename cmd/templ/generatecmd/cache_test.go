@@ -0,0 +1,152 @@
+package generatecmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHashCache(t *testing.T) {
+	code := generated(t, testTemplate)
+	tests := []struct {
+		name     string
+		change   func(t *testing.T, dir string)
+		expected bool
+	}{
+		{
+			name:     "unchanged files are up to date",
+			change:   func(t *testing.T, dir string) {},
+			expected: true,
+		},
+		{
+			name: "changed templ files are not up to date",
+			change: func(t *testing.T, dir string) {
+				writeTestFile(t, dir, "template.templ", testTemplate+"\n")
+			},
+			expected: false,
+		},
+		{
+			name: "changed generated files are not up to date",
+			change: func(t *testing.T, dir string) {
+				writeTestFile(t, dir, "template_templ.go", code+"\n")
+			},
+			expected: false,
+		},
+		{
+			name: "deleted generated files are not up to date",
+			change: func(t *testing.T, dir string) {
+				if err := os.Remove(filepath.Join(dir, "template_templ.go")); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expected: false,
+		},
+		{
+			name: "changes to other Go files in the package are not up to date",
+			change: func(t *testing.T, dir string) {
+				writeTestFile(t, dir, "other.go", "package test\n\nimport \"strings\"\n\nvar _ = strings.ToUpper\n")
+			},
+			expected: false,
+		},
+		{
+			name: "new Go files in the package are not up to date",
+			change: func(t *testing.T, dir string) {
+				writeTestFile(t, dir, "new.go", "package test\n")
+			},
+			expected: false,
+		},
+		{
+			name: "changes to other generated files in the package are up to date",
+			change: func(t *testing.T, dir string) {
+				writeTestFile(t, dir, "other_templ.go", code+"\n")
+			},
+			expected: true,
+		},
+		{
+			name: "changes to Go files in other packages are up to date",
+			change: func(t *testing.T, dir string) {
+				writeTestFile(t, dir, "sub/other.go", "package sub\n")
+			},
+			expected: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeTestFiles(t, map[string]string{
+				"template.templ":    testTemplate,
+				"template_templ.go": code,
+				"other.go":          "package test\n",
+				"other_templ.go":    code,
+			})
+			cacheFileName := filepath.Join(dir, ".templ-cache.json")
+			templFileName := filepath.Join(dir, "template.templ")
+			goFileName := filepath.Join(dir, "template_templ.go")
+
+			cache, err := loadHashCache(cacheFileName)
+			if err != nil {
+				t.Fatalf("failed to load cache: %v", err)
+			}
+			cache.Set(templFileName, []byte(testTemplate), []byte(code))
+			if err = cache.Save(); err != nil {
+				t.Fatalf("failed to save cache: %v", err)
+			}
+
+			tt.change(t, dir)
+
+			cache, err = loadHashCache(cacheFileName)
+			if err != nil {
+				t.Fatalf("failed to load cache: %v", err)
+			}
+			input, err := os.ReadFile(templFileName)
+			if err != nil {
+				t.Fatalf("failed to read templ file: %v", err)
+			}
+			if actual := cache.UpToDate(templFileName, input, goFileName); actual != tt.expected {
+				t.Errorf("expected UpToDate to return %v, got %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestHashCacheIsDiscardedWhenTheVersionChanges(t *testing.T) {
+	dir := t.TempDir()
+	cacheFileName := filepath.Join(dir, ".templ-cache.json")
+	writeTestFile(t, dir, ".templ-cache.json", `{"version":"v0.0.1","files":{"a.templ":{"input":"a","output":"b"}}}`)
+	cache, err := loadHashCache(cacheFileName)
+	if err != nil {
+		t.Fatalf("failed to load cache: %v", err)
+	}
+	if len(cache.Files) != 0 {
+		t.Errorf("expected the cache to be empty, got %v", cache.Files)
+	}
+}
+
+func TestHashCachePrune(t *testing.T) {
+	dir := writeTestFiles(t, map[string]string{
+		"template.templ": testTemplate,
+	})
+	cache, err := loadHashCache(filepath.Join(dir, ".templ-cache.json"))
+	if err != nil {
+		t.Fatalf("failed to load cache: %v", err)
+	}
+	cache.Set(filepath.Join(dir, "template.templ"), []byte(testTemplate), nil)
+	cache.Set(filepath.Join(dir, "deleted.templ"), []byte(testTemplate), nil)
+	cache.Prune()
+	if _, ok := cache.Files[filepath.Join(dir, "template.templ")]; !ok {
+		t.Error("expected existing templ files to be kept")
+	}
+	if _, ok := cache.Files[filepath.Join(dir, "deleted.templ")]; ok {
+		t.Error("expected deleted templ files to be removed")
+	}
+}
+
+func writeTestFile(t *testing.T, dir, name, contents string) {
+	t.Helper()
+	fileName := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(fileName, []byte(contents), 0644); err != nil {
+		t.Fatalf("failed to write %q: %v", name, err)
+	}
+}
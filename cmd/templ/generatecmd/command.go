@@ -0,0 +1,48 @@
+package generatecmd
+
+import (
+	"os"
+	"os/exec"
+)
+
+type runningCommand struct {
+	cmd  *exec.Cmd
+	done chan struct{}
+}
+
+// startCommand runs the command using the system shell, so that users can pass arguments
+// and use shell features, e.g. -cmd "go run ./cmd/app".
+func startCommand(command string) (rc *runningCommand, err error) {
+	cmd := shellCommand(command)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+	setProcessGroup(cmd)
+	if err = cmd.Start(); err != nil {
+		return nil, err
+	}
+	rc = &runningCommand{
+		cmd:  cmd,
+		done: make(chan struct{}),
+	}
+	go func() {
+		_ = cmd.Wait()
+		close(rc.done)
+	}()
+	return rc, nil
+}
+
+// Stop the command and any processes it started, and wait for it to exit.
+func (rc *runningCommand) Stop() error {
+	select {
+	case <-rc.done:
+		// Already exited.
+		return nil
+	default:
+	}
+	if err := killProcessGroup(rc.cmd); err != nil {
+		return err
+	}
+	<-rc.done
+	return nil
+}
@@ -0,0 +1,22 @@
+//go:build !windows
+
+package generatecmd
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+func shellCommand(command string) *exec.Cmd {
+	return exec.Command("sh", "-c", command)
+}
+
+// setProcessGroup starts the command in a new process group, so that child processes
+// (e.g. the binary started by `go run`) are stopped with it.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+func killProcessGroup(cmd *exec.Cmd) error {
+	return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
@@ -0,0 +1,19 @@
+//go:build windows
+
+package generatecmd
+
+import (
+	"os/exec"
+	"strconv"
+)
+
+func shellCommand(command string) *exec.Cmd {
+	return exec.Command("cmd", "/C", command)
+}
+
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup uses taskkill to stop the command's process tree.
+func killProcessGroup(cmd *exec.Cmd) error {
+	return exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(cmd.Process.Pid)).Run()
+}
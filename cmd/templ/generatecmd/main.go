@@ -2,18 +2,24 @@ package generatecmd
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/a-h/templ/cmd/templ/output"
 	"github.com/a-h/templ/cmd/templ/processor"
 	"github.com/a-h/templ/cmd/templ/visualize"
 	"github.com/a-h/templ/generator"
+	"github.com/a-h/templ/generator/imports"
 	"github.com/a-h/templ/parser/v2"
 )
 
@@ -22,77 +28,230 @@ type Arguments struct {
 	Path                            string
 	WorkerCount                     int
 	GenerateSourceMapVisualisations bool
+	// CacheFile is the optional name of a file used to store the hashes of templ files,
+	// so that files which haven't changed since the last run are skipped.
+	CacheFile string
+	// KeepOrphanedFiles disables the deletion of generated *_templ.go files that don't
+	// have a matching *.templ file.
+	KeepOrphanedFiles bool
+	// Watch the path for changes, and regenerate code when templates change.
+	Watch bool
+	// PollInterval is the interval used to check for changes when OS file notifications
+	// aren't available.
+	PollInterval time.Duration
+	// Command is optionally run after code is successfully generated in watch mode.
+	// If it's still running when code is next generated, it's stopped and restarted.
+	Command string
+	// Proxy is the URL of an app to proxy, e.g. http://localhost:8080. The proxy reloads
+	// the browser when code is generated. Setting it enables watch mode.
+	Proxy string
+	// ProxyPort is the port the proxy listens on.
+	ProxyPort int
+	// Verify generates code in memory and reports generated files that are out of date,
+	// without writing anything.
+	Verify bool
+	// Diff writes a unified diff for each generated file that's out of date in verify mode.
+	Diff bool
+	// LogFormat is the format of progress and errors written to stdout, text by default.
+	LogFormat output.Format
 }
 
 var defaultWorkerCount = runtime.NumCPU()
 
 func Run(args Arguments) (err error) {
-	if args.FileName != "" {
-		return processSingleFile(args.FileName, args.GenerateSourceMapVisualisations)
+	log := output.New(os.Stdout, args.LogFormat)
+	var cache *hashCache
+	if args.CacheFile != "" {
+		if cache, err = loadHashCache(args.CacheFile); err != nil {
+			return fmt.Errorf("failed to load cache: %w", err)
+		}
 	}
 	if args.WorkerCount == 0 {
 		args.WorkerCount = defaultWorkerCount
 	}
-	return processPath(args.Path, args.GenerateSourceMapVisualisations, args.WorkerCount)
+	if args.Verify {
+		if args.Watch || args.Proxy != "" {
+			return errors.New("verify mode can't be used with watch mode")
+		}
+		return verify(log, args)
+	}
+	if args.Watch || args.Proxy != "" {
+		if args.FileName != "" {
+			return errors.New("watch mode can't be used with a single file, use the path instead")
+		}
+		return watch(log, args, cache)
+	}
+	if args.FileName != "" {
+		err = processSingleFile(log, args.FileName, cache, args.GenerateSourceMapVisualisations)
+	} else {
+		err = processPath(log, args.Path, cache, args.GenerateSourceMapVisualisations, args.WorkerCount)
+		if !args.KeepOrphanedFiles {
+			err = errors.Join(err, deleteOrphanedFiles(log, args.Path))
+		}
+	}
+	if cache != nil {
+		cache.Prune()
+		if saveErr := cache.Save(); saveErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to save cache: %w", saveErr))
+		}
+	}
+	return err
 }
 
-func processSingleFile(fileName string, generateSourceMapVisualisations bool) error {
+func processSingleFile(log *output.Writer, fileName string, cache *hashCache, generateSourceMapVisualisations bool) error {
 	start := time.Now()
-	err := compile(fileName, generateSourceMapVisualisations)
+	err := compile(fileName, cache, imports.NewResolverCache(), generateSourceMapVisualisations)
+	if log.Format() == output.FormatJSON {
+		log.File(fileName, time.Since(start), err)
+		return output.Reported(err)
+	}
 	if err != nil {
 		return err
 	}
-	fmt.Printf("Generated code for %q in %s\n", fileName, time.Since(start))
-	return err
+	log.Message("Generated code for %q in %s", fileName, time.Since(start))
+	return nil
 }
 
-func processPath(path string, generateSourceMapVisualisations bool, workerCount int) (err error) {
+// processPath generates code for all templates in the path. Errors are written to the log
+// as they happen, and the returned error is marked as already reported.
+func processPath(log *output.Writer, path string, cache *hashCache, generateSourceMapVisualisations bool, workerCount int) (err error) {
 	start := time.Now()
 	results := make(chan processor.Result)
+	resolvers := imports.NewResolverCache()
 	p := func(fileName string) error {
-		return compile(fileName, generateSourceMapVisualisations)
+		return compile(fileName, cache, resolvers, generateSourceMapVisualisations)
 	}
 	go processor.Process(path, p, workerCount, results)
 	var successCount, errorCount int
 	for r := range results {
+		log.File(r.FileName, r.Duration, r.Error)
 		if r.Error != nil {
 			err = errors.Join(err, fmt.Errorf("%s: %w", r.FileName, r.Error))
 			errorCount++
 			continue
 		}
 		successCount++
-		fmt.Printf("%s complete in %v\n", r.FileName, r.Duration)
 	}
-	fmt.Printf("Generated code for %d templates with %d errors in %s\n", successCount+errorCount, errorCount, time.Since(start))
-	return err
+	log.Summary(fmt.Sprintf("Generated code for %d templates with %d errors in %s", successCount+errorCount, errorCount, time.Since(start)), successCount+errorCount, errorCount, time.Since(start))
+	return output.Reported(err)
 }
 
-func compile(fileName string, generateSourceMapVisualisations bool) (err error) {
-	t, err := parser.Parse(fileName)
+func compile(fileName string, cache *hashCache, resolvers *imports.ResolverCache, generateSourceMapVisualisations bool) (err error) {
+	input, err := os.ReadFile(fileName)
 	if err != nil {
-		return fmt.Errorf("%s parsing error: %w", fileName, err)
+		return fmt.Errorf("%s read file error: %w", fileName, err)
 	}
-	targetFileName := strings.TrimSuffix(fileName, ".templ") + "_templ.go"
-	w, err := os.Create(targetFileName)
-	if err != nil {
-		return fmt.Errorf("%s compilation error: %w", fileName, err)
+	targetFileName := goFileName(fileName)
+	if cache != nil && !generateSourceMapVisualisations && cache.UpToDate(fileName, input, targetFileName) {
+		return nil
 	}
-	defer w.Close()
-	b := bufio.NewWriter(w)
-	defer b.Flush()
-	sourceMap, err := generator.Generate(t, b)
+	b, sourceMap, err := generate(fileName, input, resolvers)
 	if err != nil {
-		return fmt.Errorf("%s generation error: %w", fileName, err)
+		return err
 	}
-	if b.Flush() != nil {
+	if err = writeIfChanged(targetFileName, b.Bytes()); err != nil {
 		return fmt.Errorf("%s write file error: %w", targetFileName, err)
 	}
+	if cache != nil {
+		cache.Set(fileName, input, b.Bytes())
+	}
 	if generateSourceMapVisualisations {
 		err = generateSourceMapVisualisation(fileName, targetFileName, sourceMap)
 	}
 	return
 }
 
+// generate Go code from the contents of the templ file. The resolver used to find missing
+// imports is shared with the other templ files in the package.
+func generate(fileName string, input []byte, resolvers *imports.ResolverCache) (b *bytes.Buffer, sourceMap *parser.SourceMap, err error) {
+	t, err := parser.ParseString(string(input))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s parsing error: %w", fileName, err)
+	}
+	b = new(bytes.Buffer)
+	sourceMap, err = generator.Generate(t, b, generator.WithFileName(fileName), generator.WithResolver(resolvers.Get(fileName)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s generation error: %w", fileName, err)
+	}
+	return b, sourceMap, nil
+}
+
+// goFileName returns the name of the Go file generated from the templ file.
+func goFileName(templFileName string) string {
+	return strings.TrimSuffix(templFileName, ".templ") + "_templ.go"
+}
+
+// writeIfChanged only writes the file if its contents are different, so that the file's
+// modification time is only updated when there's a change.
+func writeIfChanged(fileName string, contents []byte) error {
+	current, err := os.ReadFile(fileName)
+	if err == nil && bytes.Equal(current, contents) {
+		return nil
+	}
+	return os.WriteFile(fileName, contents, 0644)
+}
+
+const generatedFileHeader = "// Code generated by templ"
+
+// deleteOrphanedFiles deletes generated Go files where the templ file they were generated
+// from no longer exists.
+func deleteOrphanedFiles(log *output.Writer, path string) error {
+	fileNames, err := findOrphanedFiles(path)
+	if err != nil {
+		return err
+	}
+	for _, fileName := range fileNames {
+		if err = os.Remove(fileName); err != nil {
+			return fmt.Errorf("failed to delete orphaned file %q: %w", fileName, err)
+		}
+		log.Deleted(fileName)
+	}
+	return nil
+}
+
+// findOrphanedFiles finds generated Go files where the templ file they were generated from
+// no longer exists.
+func findOrphanedFiles(path string) (fileNames []string, err error) {
+	err = filepath.WalkDir(path, func(fileName string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if fileName != path && processor.SkipDir(d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(fileName, "_templ.go") {
+			return nil
+		}
+		templFileName := strings.TrimSuffix(fileName, "_templ.go") + ".templ"
+		if _, err = os.Stat(templFileName); err == nil || !errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if isGeneratedFile(fileName) {
+			fileNames = append(fileNames, fileName)
+		}
+		return nil
+	})
+	return fileNames, err
+}
+
+// isGeneratedFile checks the header of the file, so that hand-written files that happen to
+// end with _templ.go are not deleted.
+func isGeneratedFile(fileName string) bool {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	header := make([]byte, len(generatedFileHeader))
+	if _, err = io.ReadFull(f, header); err != nil {
+		return false
+	}
+	return string(header) == generatedFileHeader
+}
+
 func generateSourceMapVisualisation(templFileName, goFileName string, sourceMap *parser.SourceMap) error {
 	var templContents, goContents []byte
 	var templErr, goErr error
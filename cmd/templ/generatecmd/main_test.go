@@ -0,0 +1,95 @@
+package generatecmd
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/a-h/templ/cmd/templ/output"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFindOrphanedFiles(t *testing.T) {
+	code := generated(t, testTemplate)
+	dir := writeTestFiles(t, map[string]string{
+		"template.templ":                  testTemplate,
+		"template_templ.go":               code,
+		"deleted_templ.go":                code,
+		"sub/deleted_templ.go":            code,
+		"handwritten_templ.go":            "package test\n",
+		"vendor/example.com/a_templ.go":   code,
+		"node_modules/example/a_templ.go": code,
+		".git/a_templ.go":                 code,
+	})
+	actual, err := findOrphanedFiles(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{
+		filepath.Join(dir, "deleted_templ.go"),
+		filepath.Join(dir, "sub", "deleted_templ.go"),
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestDeleteOrphanedFiles(t *testing.T) {
+	code := generated(t, testTemplate)
+	dir := writeTestFiles(t, map[string]string{
+		"template.templ":       testTemplate,
+		"template_templ.go":    code,
+		"deleted_templ.go":     code,
+		"handwritten_templ.go": "package test\n",
+	})
+	var buf bytes.Buffer
+	if err := deleteOrphanedFiles(output.New(&buf, output.FormatText), dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, name := range []string{"template.templ", "template_templ.go", "handwritten_templ.go"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("expected %q to be kept: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "deleted_templ.go")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected orphaned file to be deleted, got %v", err)
+	}
+	if !strings.Contains(buf.String(), "deleted_templ.go") {
+		t.Errorf("expected the deleted file to be logged, got %q", buf.String())
+	}
+}
+
+func TestWriteIfChanged(t *testing.T) {
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, "template_templ.go")
+	if err := writeIfChanged(fileName, []byte("a")); err != nil {
+		t.Fatalf("failed to write new file: %v", err)
+	}
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(fileName, past, past); err != nil {
+		t.Fatalf("failed to set modification time: %v", err)
+	}
+
+	if err := writeIfChanged(fileName, []byte("a")); err != nil {
+		t.Fatalf("failed to write unchanged file: %v", err)
+	}
+	info, err := os.Stat(fileName)
+	if err != nil {
+		t.Fatalf("failed to stat file: %v", err)
+	}
+	if !info.ModTime().Equal(past) {
+		t.Errorf("expected unchanged file not to be written, got modification time %v", info.ModTime())
+	}
+
+	if err := writeIfChanged(fileName, []byte("b")); err != nil {
+		t.Fatalf("failed to write changed file: %v", err)
+	}
+	if data, err := os.ReadFile(fileName); err != nil || string(data) != "b" {
+		t.Errorf("expected changed file to be written, got %q, %v", data, err)
+	}
+}
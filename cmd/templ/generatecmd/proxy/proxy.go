@@ -0,0 +1,135 @@
+// Package proxy provides a reverse proxy used during development, which injects a script
+// into HTML responses that reloads the page when templates are regenerated.
+package proxy
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// ScriptPath is the path that the reload script is served from.
+	ScriptPath = "/_templ/reload/script.js"
+	// EventsPath is the path of the server-sent events stream that tells the browser to reload.
+	EventsPath = "/_templ/reload/events"
+)
+
+//go:embed script.js
+var script []byte
+
+var scriptTag = []byte(`<script src="` + ScriptPath + `"></script>`)
+
+// Handler proxies requests to the target, and notifies browsers when they should reload.
+type Handler struct {
+	target *url.URL
+	p      *httputil.ReverseProxy
+	sse    *sseHandler
+}
+
+// New creates a proxy for the target URL, e.g. http://localhost:8080.
+func New(target *url.URL) *Handler {
+	p := httputil.NewSingleHostReverseProxy(target)
+	director := p.Director
+	p.Director = func(r *http.Request) {
+		director(r)
+		// Compressed responses can't have the script inserted into them.
+		r.Header.Del("Accept-Encoding")
+	}
+	p.ModifyResponse = modifyResponse
+	p.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+		http.Error(w, fmt.Sprintf("templ proxy: failed to reach %s: %v", target.String(), err), http.StatusBadGateway)
+	}
+	return &Handler{
+		target: target,
+		p:      p,
+		sse:    newSSEHandler(),
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case ScriptPath:
+		w.Header().Set("Content-Type", "text/javascript")
+		w.Header().Set("Cache-Control", "no-store")
+		_, _ = w.Write(script)
+	case EventsPath:
+		h.sse.ServeHTTP(w, r)
+	default:
+		h.p.ServeHTTP(w, r)
+	}
+}
+
+// Reload tells connected browsers to reload the page.
+func (h *Handler) Reload() {
+	h.sse.Send("reload")
+}
+
+// WaitForTarget waits until the target responds to requests, e.g. after the app is restarted,
+// or until the context is cancelled.
+func (h *Handler) WaitForTarget(ctx context.Context) error {
+	client := &http.Client{
+		Timeout: time.Second,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.target.String(), nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err == nil {
+			resp.Body.Close()
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+func modifyResponse(r *http.Response) error {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "text/html") {
+		return nil
+	}
+	if ce := r.Header.Get("Content-Encoding"); ce != "" && ce != "identity" {
+		return nil
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	if err = r.Body.Close(); err != nil {
+		return err
+	}
+	body = InsertScriptTag(body)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	r.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	return nil
+}
+
+// InsertScriptTag adds the reload script tag to the HTML, before the closing body tag if
+// there is one, or at the end of the document if not.
+func InsertScriptTag(html []byte) []byte {
+	op := make([]byte, 0, len(html)+len(scriptTag))
+	if index := bytes.LastIndex(bytes.ToLower(html), []byte("</body>")); index >= 0 {
+		op = append(op, html[:index]...)
+		op = append(op, scriptTag...)
+		return append(op, html[index:]...)
+	}
+	op = append(op, html...)
+	return append(op, scriptTag...)
+}
@@ -0,0 +1,90 @@
+package proxy
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestInsertScriptTag(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "the script is inserted before the closing body tag",
+			input:    `<html><body><div>Hello</div></body></html>`,
+			expected: `<html><body><div>Hello</div><script src="/_templ/reload/script.js"></script></body></html>`,
+		},
+		{
+			name:     "the body tag is matched case insensitively",
+			input:    `<HTML><BODY>Hello</BODY></HTML>`,
+			expected: `<HTML><BODY>Hello<script src="/_templ/reload/script.js"></script></BODY></HTML>`,
+		},
+		{
+			name:     "the script is appended to fragments without a body",
+			input:    `<div>Hello</div>`,
+			expected: `<div>Hello</div><script src="/_templ/reload/script.js"></script>`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			actual := string(InsertScriptTag([]byte(tt.input)))
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/data.json" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, `{"body":"</body>"}`)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = io.WriteString(w, `<html><body>Hello</body></html>`)
+	}))
+	defer target.Close()
+	u, err := url.Parse(target.URL)
+	if err != nil {
+		t.Fatalf("failed to parse URL: %v", err)
+	}
+	h := New(u)
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{
+			path:     "/",
+			expected: `<html><body>Hello<script src="/_templ/reload/script.js"></script></body></html>`,
+		},
+		{
+			path:     "/data.json",
+			expected: `{"body":"</body>"}`,
+		},
+		{
+			path:     ScriptPath,
+			expected: string(script),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if diff := cmp.Diff(tt.expected, w.Body.String()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package proxy
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// sseHandler streams server-sent events to each connected browser.
+type sseHandler struct {
+	m        sync.Mutex
+	requests map[int64]chan string
+	counter  int64
+}
+
+func newSSEHandler() *sseHandler {
+	return &sseHandler{
+		requests: make(map[int64]chan string),
+	}
+}
+
+// Send the event data to all connected browsers.
+func (s *sseHandler) Send(data string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	for _, events := range s.requests {
+		select {
+		case events <- data:
+		default:
+			// The browser already has an event waiting.
+		}
+	}
+}
+
+func (s *sseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	events := make(chan string, 1)
+	s.m.Lock()
+	s.counter++
+	id := s.counter
+	s.requests[id] = events
+	s.m.Unlock()
+	defer func() {
+		s.m.Lock()
+		delete(s.requests, id)
+		s.m.Unlock()
+	}()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case data := <-events:
+			if _, err := fmt.Fprintf(w, "event: message\ndata: %s\n\n", data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package generatecmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/a-h/templ/cmd/templ/output"
+	"github.com/a-h/templ/cmd/templ/processor"
+	"github.com/a-h/templ/generator/imports"
+)
+
+// verify generates code in memory, and reports generated files that don't match the code
+// on disk, without writing anything. It returns an error if any files are out of date.
+func verify(log *output.Writer, args Arguments) (err error) {
+	start := time.Now()
+	resolvers := imports.NewResolverCache()
+	f := func(fileName string) error {
+		return verifyFile(fileName, resolvers, args.Diff)
+	}
+	results := make(chan processor.Result)
+	path := args.Path
+	if args.FileName != "" {
+		path = args.FileName
+	}
+	go processor.Process(path, f, args.WorkerCount, results)
+	var successCount, errorCount, outdatedCount int
+	for r := range results {
+		var od outdatedError
+		if errors.As(r.Error, &od) {
+			log.Changed(od.fileName, output.StatusOutdated, od.diff)
+			outdatedCount++
+			successCount++
+			continue
+		}
+		if r.Error != nil {
+			log.File(r.FileName, r.Duration, r.Error)
+			err = errors.Join(err, fmt.Errorf("%s: %w", r.FileName, r.Error))
+			errorCount++
+			continue
+		}
+		successCount++
+	}
+	if args.FileName == "" && !args.KeepOrphanedFiles {
+		orphans, orphanErr := findOrphanedFiles(args.Path)
+		if orphanErr != nil {
+			log.Error(orphanErr)
+			err = errors.Join(err, orphanErr)
+		}
+		for _, fileName := range orphans {
+			log.Changed(fileName, output.StatusOrphaned, "")
+			outdatedCount++
+		}
+	}
+	log.Summary(fmt.Sprintf("Verified %d templates with %d errors in %s", successCount+errorCount, errorCount, time.Since(start)), successCount+errorCount, errorCount, time.Since(start))
+	err = output.Reported(err)
+	if outdatedCount > 0 {
+		err = errors.Join(err, fmt.Errorf("%d generated files are out of date, run templ generate", outdatedCount))
+	}
+	return err
+}
+
+// outdatedError is returned when the generated code for a templ file doesn't match the
+// code on disk.
+type outdatedError struct {
+	fileName string
+	diff     string
+}
+
+func (e outdatedError) Error() string {
+	return fmt.Sprintf("%s is out of date", e.fileName)
+}
+
+func verifyFile(fileName string, resolvers *imports.ResolverCache, diff bool) error {
+	input, err := os.ReadFile(fileName)
+	if err != nil {
+		return fmt.Errorf("%s read file error: %w", fileName, err)
+	}
+	expected, _, err := generate(fileName, input, resolvers)
+	if err != nil {
+		return err
+	}
+	targetFileName := goFileName(fileName)
+	actual, err := os.ReadFile(targetFileName)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%s read file error: %w", targetFileName, err)
+	}
+	if bytes.Equal(withoutVersion(actual), withoutVersion(expected.Bytes())) {
+		return nil
+	}
+	od := outdatedError{
+		fileName: targetFileName,
+	}
+	if diff {
+		if od.diff, err = output.UnifiedDiff(targetFileName, actual, expected.Bytes()); err != nil {
+			return fmt.Errorf("%s diff error: %w", targetFileName, err)
+		}
+	}
+	return od
+}
+
+// withoutVersion removes the templ version from the generated code header, so that files
+// generated by a different version of templ aren't reported as out of date.
+func withoutVersion(code []byte) []byte {
+	prefix := []byte(generatedFileHeader + "@")
+	if !bytes.HasPrefix(code, prefix) {
+		return code
+	}
+	end := bytes.IndexByte(code, '\n')
+	if end < 0 {
+		return code
+	}
+	header := code[:end]
+	if !bytes.HasSuffix(header, []byte(" DO NOT EDIT.")) {
+		return code
+	}
+	return append([]byte(generatedFileHeader+" DO NOT EDIT."), code[end:]...)
+}
@@ -0,0 +1,165 @@
+package generatecmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/a-h/templ/cmd/templ/output"
+	"github.com/a-h/templ/generator/imports"
+)
+
+const testTemplate = `package test
+
+templ Hello(name string) {
+	<div>{ name }</div>
+}
+`
+
+// writeTestFiles writes the files to a temporary directory, and returns its path.
+func writeTestFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, contents := range files {
+		writeTestFile(t, dir, name, contents)
+	}
+	return dir
+}
+
+// generated returns the Go code generated from the template.
+func generated(t *testing.T, template string) string {
+	t.Helper()
+	b, _, err := generate("template.templ", []byte(template), imports.NewResolverCache())
+	if err != nil {
+		t.Fatalf("failed to generate code: %v", err)
+	}
+	return b.String()
+}
+
+func TestVerify(t *testing.T) {
+	code := generated(t, testTemplate)
+	tests := []struct {
+		name             string
+		files            map[string]string
+		expectedErr      bool
+		expectedOutput   []string
+		unexpectedOutput []string
+	}{
+		{
+			name: "up to date files pass",
+			files: map[string]string{
+				"template.templ":    testTemplate,
+				"template_templ.go": code,
+			},
+		},
+		{
+			name: "files generated by a different version of templ pass",
+			files: map[string]string{
+				"template.templ":    testTemplate,
+				"template_templ.go": withVersion(t, code, "v0.0.1"),
+			},
+		},
+		{
+			name: "outdated files fail",
+			files: map[string]string{
+				"template.templ":    testTemplate,
+				"template_templ.go": strings.Replace(code, "<div>", "<span>", 1),
+			},
+			expectedErr:    true,
+			expectedOutput: []string{"template_templ.go", output.StatusOutdated, "+", "-"},
+		},
+		{
+			name: "missing generated files fail",
+			files: map[string]string{
+				"template.templ": testTemplate,
+			},
+			expectedErr:    true,
+			expectedOutput: []string{"template_templ.go", output.StatusOutdated},
+		},
+		{
+			name: "orphaned generated files fail",
+			files: map[string]string{
+				"template.templ":       testTemplate,
+				"template_templ.go":    code,
+				"deleted_templ.go":     code,
+				"handwritten_templ.go": "package test\n",
+			},
+			expectedErr:      true,
+			expectedOutput:   []string{"deleted_templ.go", output.StatusOrphaned},
+			unexpectedOutput: []string{"handwritten_templ.go"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeTestFiles(t, tt.files)
+			before := readFiles(t, dir)
+			var buf bytes.Buffer
+			err := verify(output.New(&buf, output.FormatJSON), Arguments{
+				Path:        dir,
+				WorkerCount: 1,
+				Diff:        true,
+			})
+			if tt.expectedErr && err == nil {
+				t.Errorf("expected an error, got nil\n%s", buf.String())
+			}
+			if !tt.expectedErr && err != nil {
+				t.Errorf("unexpected error: %v\n%s", err, buf.String())
+			}
+			for _, s := range tt.expectedOutput {
+				if !strings.Contains(buf.String(), s) {
+					t.Errorf("expected output to contain %q, got:\n%s", s, buf.String())
+				}
+			}
+			for _, s := range tt.unexpectedOutput {
+				if strings.Contains(buf.String(), s) {
+					t.Errorf("expected output not to contain %q, got:\n%s", s, buf.String())
+				}
+			}
+			if after := readFiles(t, dir); !equalFiles(before, after) {
+				t.Error("expected verify not to change any files")
+			}
+		})
+	}
+}
+
+// withVersion replaces the templ version in the header of the generated code.
+func withVersion(t *testing.T, code, version string) string {
+	t.Helper()
+	header, rest, ok := strings.Cut(code, "\n")
+	if !ok || !strings.HasPrefix(header, generatedFileHeader+"@") {
+		t.Fatalf("unexpected header: %q", header)
+	}
+	return generatedFileHeader + "@" + version + " DO NOT EDIT.\n" + rest
+}
+
+// readFiles returns the contents of all of the files in the directory.
+func readFiles(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	files := make(map[string]string)
+	err := filepath.WalkDir(dir, func(fileName string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		b, err := os.ReadFile(fileName)
+		files[fileName] = string(b)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to read files: %v", err)
+	}
+	return files
+}
+
+func equalFiles(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return true
+}
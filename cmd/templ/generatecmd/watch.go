@@ -0,0 +1,396 @@
+package generatecmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/a-h/templ/cmd/templ/generatecmd/proxy"
+	"github.com/a-h/templ/cmd/templ/output"
+	"github.com/a-h/templ/cmd/templ/processor"
+	"github.com/a-h/templ/generator/imports"
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	// debounceDuration is the time to wait after the last change before generating code,
+	// since editors often write several events when saving a file.
+	debounceDuration = 100 * time.Millisecond
+	// defaultPollInterval is used when OS file notifications aren't available.
+	defaultPollInterval = 500 * time.Millisecond
+	// defaultProxyPort is the port the live-reload proxy listens on.
+	defaultProxyPort = 7331
+	// reloadTimeout is how long to wait for the proxy target to start responding after
+	// code is generated.
+	reloadTimeout = 30 * time.Second
+)
+
+// watch generates code for all templates, then regenerates code for templates as they change.
+// Errors are printed, but don't stop the watch. It runs until the process is interrupted.
+func watch(log *output.Writer, args Arguments, cache *hashCache) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cmd := newCommandRunner(log, args.Command)
+	defer cmd.Stop()
+
+	var p *proxy.Handler
+	if args.Proxy != "" {
+		var err error
+		if p, err = startProxy(ctx, log, args.Proxy, args.ProxyPort); err != nil {
+			return err
+		}
+	}
+
+	var reloadCancel context.CancelFunc = func() {}
+	defer func() { reloadCancel() }()
+	w := &watcher{
+		log:   log,
+		args:  args,
+		cache: cache,
+		onGenerated: func() {
+			cmd.Restart()
+			if p == nil {
+				return
+			}
+			// Reload the browser once the app has restarted, unless code is generated
+			// again in the meantime.
+			reloadCancel()
+			var reloadCtx context.Context
+			reloadCtx, reloadCancel = context.WithTimeout(ctx, reloadTimeout)
+			go func() {
+				if err := p.WaitForTarget(reloadCtx); err != nil {
+					if !errors.Is(err, context.Canceled) {
+						log.Message("Proxy target %q did not respond: %v", args.Proxy, err)
+					}
+					return
+				}
+				p.Reload()
+			}()
+		},
+	}
+	return w.Run(ctx)
+}
+
+// startProxy starts a live-reload proxy for the target URL, which runs until the context
+// is cancelled.
+func startProxy(ctx context.Context, log *output.Writer, target string, port int) (*proxy.Handler, error) {
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse proxy URL: %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q, expected a URL such as http://localhost:8080", target)
+	}
+	if port == 0 {
+		port = defaultProxyPort
+	}
+	p := proxy.New(u)
+	server := &http.Server{
+		Addr:    fmt.Sprintf("127.0.0.1:%d", port),
+		Handler: p,
+	}
+	listener, err := net.Listen("tcp", server.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start proxy: %w", err)
+	}
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error(fmt.Errorf("proxy error: %w", err))
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+	log.Message("Proxying from http://%s to %s", server.Addr, u.String())
+	return p, nil
+}
+
+type watcher struct {
+	log   *output.Writer
+	args  Arguments
+	cache *hashCache
+	// onGenerated is called after each successful generation.
+	onGenerated func()
+}
+
+func (w *watcher) Run(ctx context.Context) error {
+	if err := processPath(w.log, w.args.Path, w.cache, w.args.GenerateSourceMapVisualisations, w.args.WorkerCount); err == nil {
+		w.onGenerated()
+	}
+	if !w.args.KeepOrphanedFiles {
+		if err := deleteOrphanedFiles(w.log, w.args.Path); err != nil {
+			w.log.Error(err)
+		}
+	}
+	w.saveCache()
+
+	changes := make(chan string)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- w.notify(ctx, changes)
+	}()
+	w.log.Message("Watching %q for changes", w.args.Path)
+
+	pending := make(map[string]struct{})
+	timer := time.NewTimer(debounceDuration)
+	timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errs:
+			return err
+		case fileName := <-changes:
+			pending[fileName] = struct{}{}
+			timer.Reset(debounceDuration)
+		case <-timer.C:
+			fileNames := make([]string, 0, len(pending))
+			for fileName := range pending {
+				fileNames = append(fileNames, fileName)
+			}
+			pending = make(map[string]struct{})
+			sort.Strings(fileNames)
+			if w.regenerate(fileNames) {
+				w.onGenerated()
+			}
+			w.saveCache()
+		}
+	}
+}
+
+// regenerate code for the changed templ files, returning true if all succeeded.
+func (w *watcher) regenerate(fileNames []string) (ok bool) {
+	start := time.Now()
+	// Other Go files in the module may have changed, so resolvers aren't kept between runs.
+	resolvers := imports.NewResolverCache()
+	var errorCount int
+	for _, fileName := range fileNames {
+		if _, err := os.Stat(fileName); errors.Is(err, fs.ErrNotExist) {
+			if w.args.KeepOrphanedFiles {
+				continue
+			}
+			targetFileName := goFileName(fileName)
+			if isGeneratedFile(targetFileName) {
+				if err = os.Remove(targetFileName); err != nil {
+					w.log.File(targetFileName, 0, fmt.Errorf("%s: failed to delete orphaned file: %w", targetFileName, err))
+					errorCount++
+					continue
+				}
+				w.log.Deleted(targetFileName)
+			}
+			continue
+		}
+		fileStart := time.Now()
+		err := compile(fileName, w.cache, resolvers, w.args.GenerateSourceMapVisualisations)
+		w.log.File(fileName, time.Since(fileStart), err)
+		if err != nil {
+			errorCount++
+		}
+	}
+	w.log.Summary(fmt.Sprintf("Generated code for %d templates with %d errors in %s", len(fileNames), errorCount, time.Since(start)), len(fileNames), errorCount, time.Since(start))
+	return errorCount == 0
+}
+
+func (w *watcher) saveCache() {
+	if w.cache == nil {
+		return
+	}
+	if err := w.cache.Save(); err != nil {
+		w.log.Error(fmt.Errorf("failed to save cache: %w", err))
+	}
+}
+
+// notify sends the names of changed templ files to the channel, using OS file notifications
+// if they're available, or polling if not.
+func (w *watcher) notify(ctx context.Context, changes chan<- string) error {
+	err := notifyFS(ctx, w.log, w.args.Path, changes)
+	if err == nil {
+		return nil
+	}
+	w.log.Message("File notifications are not available (%v), polling for changes instead", err)
+	interval := w.args.PollInterval
+	if interval == 0 {
+		interval = defaultPollInterval
+	}
+	return notifyPoll(ctx, w.args.Path, interval, changes)
+}
+
+func notifyFS(ctx context.Context, log *output.Writer, root string, changes chan<- string) error {
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer fw.Close()
+	if err = addDirs(fw, root); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err, ok := <-fw.Errors:
+			if !ok {
+				return nil
+			}
+			log.Error(fmt.Errorf("file watcher error: %w", err))
+		case event, ok := <-fw.Events:
+			if !ok {
+				return nil
+			}
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					// New directories need to be watched, and may already contain templates.
+					if err = addDirs(fw, event.Name); err != nil {
+						log.Error(fmt.Errorf("file watcher error: %w", err))
+					}
+					for _, fileName := range findTemplates(event.Name) {
+						send(ctx, changes, fileName)
+					}
+					continue
+				}
+			}
+			if strings.HasSuffix(event.Name, ".templ") && !event.Has(fsnotify.Chmod) {
+				send(ctx, changes, filepath.Clean(event.Name))
+			}
+		}
+	}
+}
+
+func addDirs(fw *fsnotify.Watcher, root string) error {
+	return filepath.WalkDir(root, func(dir string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if dir != root && processor.SkipDir(d.Name()) {
+			return filepath.SkipDir
+		}
+		return fw.Add(dir)
+	})
+}
+
+func notifyPoll(ctx context.Context, root string, interval time.Duration, changes chan<- string) error {
+	previous := modTimes(root)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			current := modTimes(root)
+			for fileName, modTime := range current {
+				if previousModTime, ok := previous[fileName]; !ok || !previousModTime.Equal(modTime) {
+					send(ctx, changes, fileName)
+				}
+			}
+			for fileName := range previous {
+				if _, ok := current[fileName]; !ok {
+					send(ctx, changes, fileName)
+				}
+			}
+			previous = current
+		}
+	}
+}
+
+func modTimes(root string) map[string]time.Time {
+	times := make(map[string]time.Time)
+	for _, fileName := range findTemplates(root) {
+		if info, err := os.Stat(fileName); err == nil {
+			times[fileName] = info.ModTime()
+		}
+	}
+	return times
+}
+
+func findTemplates(root string) (fileNames []string) {
+	_ = filepath.WalkDir(root, func(fileName string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if fileName != root && processor.SkipDir(d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(fileName, ".templ") {
+			fileNames = append(fileNames, fileName)
+		}
+		return nil
+	})
+	return fileNames
+}
+
+func send(ctx context.Context, changes chan<- string, fileName string) {
+	select {
+	case <-ctx.Done():
+	case changes <- fileName:
+	}
+}
+
+// newCommandRunner creates a runner for a command that's restarted after code is generated.
+func newCommandRunner(log *output.Writer, command string) *commandRunner {
+	return &commandRunner{
+		log:     log,
+		command: command,
+	}
+}
+
+type commandRunner struct {
+	m       sync.Mutex
+	log     *output.Writer
+	command string
+	current *runningCommand
+}
+
+// Restart stops the command if it's running, and starts it again.
+func (r *commandRunner) Restart() {
+	if r.command == "" {
+		return
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.stop()
+	r.log.Message("Running %q", r.command)
+	rc, err := startCommand(r.command)
+	if err != nil {
+		r.log.Error(fmt.Errorf("failed to run command %q: %w", r.command, err))
+		return
+	}
+	r.current = rc
+}
+
+// Stop the command if it's running.
+func (r *commandRunner) Stop() {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.stop()
+}
+
+func (r *commandRunner) stop() {
+	if r.current == nil {
+		return
+	}
+	if err := r.current.Stop(); err != nil {
+		r.log.Error(fmt.Errorf("failed to stop command %q: %w", r.command, err))
+	}
+	r.current = nil
+}
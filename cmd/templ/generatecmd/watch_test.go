@@ -0,0 +1,137 @@
+package generatecmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/a-h/templ/cmd/templ/output"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWatcherRun(t *testing.T) {
+	dir := writeTestFiles(t, map[string]string{
+		"template.templ": testTemplate,
+	})
+	done := make(chan struct{}, 10)
+	w := &watcher{
+		log: output.New(io.Discard, output.FormatText),
+		args: Arguments{
+			Path:         dir,
+			WorkerCount:  1,
+			PollInterval: 10 * time.Millisecond,
+		},
+		onGenerated: func() {
+			done <- struct{}{}
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errs := make(chan error, 1)
+	go func() {
+		errs <- w.Run(ctx)
+	}()
+
+	waitForGeneration(t, done)
+	goFileName := filepath.Join(dir, "template_templ.go")
+	if _, err := os.Stat(goFileName); err != nil {
+		t.Fatalf("expected code to be generated on startup: %v", err)
+	}
+
+	updated := `package test
+
+templ Hello(name string) {
+	<span>{ name }</span>
+}
+`
+	// Give the watcher time to start watching the directory.
+	time.Sleep(100 * time.Millisecond)
+	writeTestFile(t, dir, "template.templ", updated)
+	waitForGeneration(t, done)
+	select {
+	case <-done:
+		t.Error("expected a single change to cause a single regeneration")
+	case <-time.After(debounceDuration * 3):
+	}
+	actual, err := os.ReadFile(goFileName)
+	if err != nil {
+		t.Fatalf("failed to read generated code: %v", err)
+	}
+	if diff := cmp.Diff(generated(t, updated), string(actual)); diff != "" {
+		t.Error(diff)
+	}
+
+	cancel()
+	if err := <-errs; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func waitForGeneration(t *testing.T, done <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for code to be generated")
+	}
+}
+
+func TestNotifyPoll(t *testing.T) {
+	dir := writeTestFiles(t, map[string]string{
+		"a.templ":                  testTemplate,
+		"sub/b.templ":              testTemplate,
+		"node_modules/pkg/c.templ": testTemplate,
+		".git/d.templ":             testTemplate,
+		"vendor/e.templ":           testTemplate,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := make(chan string)
+	go func() {
+		_ = notifyPoll(ctx, dir, 10*time.Millisecond, changes)
+	}()
+	// Wait for the first poll, so that the changes are seen.
+	time.Sleep(50 * time.Millisecond)
+
+	// Set the modification times explicitly, since some file systems have a low resolution.
+	future := time.Now().Add(time.Hour)
+	for _, name := range []string{"a.templ", "sub/b.templ", "node_modules/pkg/c.templ", ".git/d.templ", "vendor/e.templ"} {
+		if err := os.Chtimes(filepath.Join(dir, name), future, future); err != nil {
+			t.Fatalf("failed to set modification time: %v", err)
+		}
+	}
+	var actual []string
+	timeout := time.After(5 * time.Second)
+	for len(actual) < 2 {
+		select {
+		case fileName := <-changes:
+			actual = append(actual, fileName)
+		case <-timeout:
+			t.Fatalf("timed out waiting for changes, got %v", actual)
+		}
+	}
+	select {
+	case fileName := <-changes:
+		actual = append(actual, fileName)
+	case <-time.After(100 * time.Millisecond):
+	}
+	sort.Strings(actual)
+	expected := []string{filepath.Join(dir, "a.templ"), filepath.Join(dir, "sub", "b.templ")}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestRunRejectsWatchingASingleFile(t *testing.T) {
+	err := Run(Arguments{
+		FileName: "template.templ",
+		Watch:    true,
+	})
+	if err == nil {
+		t.Error("expected an error, got nil")
+	}
+}
@@ -0,0 +1,279 @@
+package lintcmd
+
+import (
+	"strconv"
+	"strings"
+
+	parser "github.com/a-h/templ/parser/v2"
+)
+
+// The accessibility rules only report attributes that are provably missing or invalid.
+// Attributes set by expressions, or in one branch of a conditional attribute, could
+// have any value, so they're not reported.
+
+var ImageAlt = Rule{
+	Name:     "a11y-img-alt",
+	Doc:      "Reports <img> elements without an alt attribute. Use alt=\"\" for decorative images.",
+	Severity: SeverityWarning,
+	Run: func(p *Pass) {
+		p.Inspect(func(node any, ancestors []any) bool {
+			e, ok := node.(parser.Element)
+			if !ok || !strings.EqualFold(e.Name, "img") {
+				return true
+			}
+			if !attributeOf(e, "alt").found {
+				p.Report(e.Range, "<img> must have an alt attribute")
+			}
+			return true
+		})
+	},
+}
+
+var FormLabel = Rule{
+	Name:     "a11y-form-label",
+	Doc:      "Reports form controls that aren't inside a <label>, aren't referred to by a label's for attribute, and don't have an aria-label or aria-labelledby attribute.",
+	Severity: SeverityWarning,
+	Run: func(p *Pass) {
+		labelFor, allLabelsKnown := labelTargets(p.File)
+		p.Inspect(func(node any, ancestors []any) bool {
+			e, ok := node.(parser.Element)
+			if !ok || !isLabelable(e) || hasAncestor(ancestors, "label") {
+				return true
+			}
+			if attributeOf(e, "aria-label").found || attributeOf(e, "aria-labelledby").found {
+				return true
+			}
+			if id := attributeOf(e, "id"); id.found {
+				if !id.constant || !allLabelsKnown {
+					return true
+				}
+				if _, hasLabel := labelFor[id.value]; hasLabel {
+					return true
+				}
+			}
+			p.Report(e.Range, "<%s> must have a label", e.Name)
+			return true
+		})
+	},
+}
+
+// isLabelable returns true for form controls that need a label.
+func isLabelable(e parser.Element) bool {
+	switch strings.ToLower(e.Name) {
+	case "select", "textarea":
+		return true
+	case "input":
+		t := attributeOf(e, "type")
+		if !t.found {
+			return true
+		}
+		if !t.constant {
+			return false
+		}
+		switch strings.ToLower(t.value) {
+		case "hidden", "submit", "reset", "button", "image":
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+// labelTargets returns the values of the for attributes of the labels in the file. If
+// any label has a for attribute that isn't constant, allKnown is false.
+func labelTargets(tf parser.TemplateFile) (ids map[string]struct{}, allKnown bool) {
+	ids = map[string]struct{}{}
+	allKnown = true
+	inspect(tf, func(node any, ancestors []any) bool {
+		e, ok := node.(parser.Element)
+		if !ok || !strings.EqualFold(e.Name, "label") {
+			return true
+		}
+		if f := attributeOf(e, "for"); f.found {
+			if !f.constant {
+				allKnown = false
+			}
+			ids[f.value] = struct{}{}
+		}
+		return true
+	})
+	return ids, allKnown
+}
+
+var ARIA = Rule{
+	Name:     "a11y-aria",
+	Doc:      "Reports aria-* attributes that aren't ARIA attributes, and role attributes that aren't ARIA roles.",
+	Severity: SeverityWarning,
+	Run: func(p *Pass) {
+		p.Inspect(func(node any, ancestors []any) bool {
+			name, r, ok := attributeName(node)
+			if !ok {
+				return true
+			}
+			name = strings.ToLower(name)
+			if strings.HasPrefix(name, "aria-") {
+				if _, known := ariaAttributes[name]; !known {
+					p.Report(r, "unknown ARIA attribute %q", name)
+				}
+				return true
+			}
+			if a, isConstant := node.(parser.ConstantAttribute); isConstant && name == "role" {
+				// The role attribute can list fallback roles.
+				for _, role := range strings.Fields(a.Value) {
+					if _, known := ariaRoles[strings.ToLower(role)]; !known {
+						p.Report(r, "unknown ARIA role %q", role)
+					}
+				}
+			}
+			return true
+		})
+	},
+}
+
+var ButtonText = Rule{
+	Name:     "a11y-button-text",
+	Doc:      "Reports buttons that don't contain text, and don't have an aria-label, aria-labelledby or title attribute.",
+	Severity: SeverityWarning,
+	Run: func(p *Pass) {
+		p.Inspect(func(node any, ancestors []any) bool {
+			e, ok := node.(parser.Element)
+			if !ok || !strings.EqualFold(e.Name, "button") {
+				return true
+			}
+			if !hasAccessibleName(e) && !mayContainText(e.Children) {
+				p.Report(e.Range, "<button> must contain text, or have an aria-label")
+			}
+			return true
+		})
+	},
+}
+
+func hasAccessibleName(e parser.Element) bool {
+	return attributeOf(e, "aria-label").found || attributeOf(e, "aria-labelledby").found || attributeOf(e, "title").found
+}
+
+// mayContainText returns false if the nodes provably don't contain text, or elements
+// with text alternatives.
+func mayContainText(nodes []parser.Node) bool {
+	for _, n := range nodes {
+		switch n := n.(type) {
+		case parser.Whitespace, parser.Comment, parser.GoCode:
+			continue
+		case parser.Text:
+			if strings.TrimSpace(n.Value) != "" {
+				return true
+			}
+		case parser.Element:
+			if hasAccessibleName(n) || attributeOf(n, "alt").found || mayContainText(n.Children) {
+				return true
+			}
+		default:
+			// Expressions, templ elements and control flow could render anything.
+			return true
+		}
+	}
+	return false
+}
+
+var PositiveTabIndex = Rule{
+	Name:     "a11y-tabindex",
+	Doc:      "Reports tabindex attributes greater than zero, which change the keyboard navigation order of the page.",
+	Severity: SeverityWarning,
+	Run: func(p *Pass) {
+		p.Inspect(func(node any, ancestors []any) bool {
+			a, ok := node.(parser.ConstantAttribute)
+			if !ok || !strings.EqualFold(a.Name, "tabindex") {
+				return true
+			}
+			if i, err := strconv.Atoi(strings.TrimSpace(a.Value)); err == nil && i > 0 {
+				p.Report(a.Range, "tabindex should be 0 or -1, not %d", i)
+			}
+			return true
+		})
+	},
+}
+
+// attributeValue is what's known about an attribute of an element.
+type attributeValue struct {
+	// found is true if the attribute is, or might be, set.
+	found bool
+	// constant is true if the value is known.
+	constant bool
+	value    string
+}
+
+// attributeOf returns the value of the named attribute of an element.
+func attributeOf(e parser.Element, name string) attributeValue {
+	return findAttribute(e.Attributes, name)
+}
+
+func findAttribute(attributes []parser.Attribute, name string) (v attributeValue) {
+	for _, a := range attributes {
+		switch a := a.(type) {
+		case parser.ConstantAttribute:
+			if strings.EqualFold(a.Name, name) {
+				return attributeValue{found: true, constant: true, value: a.Value}
+			}
+		case parser.BoolConstantAttribute:
+			if strings.EqualFold(a.Name, name) {
+				return attributeValue{found: true, constant: true}
+			}
+		case parser.ExpressionAttribute:
+			if strings.EqualFold(a.Name, name) {
+				return attributeValue{found: true}
+			}
+		case parser.BoolExpressionAttribute:
+			if strings.EqualFold(a.Name, name) {
+				return attributeValue{found: true}
+			}
+		case parser.InterpolatedAttribute:
+			if strings.EqualFold(a.Name, name) {
+				return attributeValue{found: true}
+			}
+		case parser.ConditionalAttribute:
+			if findAttribute(a.Then, name).found || findAttribute(a.Else, name).found {
+				v = attributeValue{found: true}
+			}
+			for _, elseIf := range a.ElseIfs {
+				if findAttribute(elseIf.Then, name).found {
+					v = attributeValue{found: true}
+				}
+			}
+		}
+	}
+	return v
+}
+
+func hasAncestor(ancestors []any, name string) bool {
+	for _, a := range ancestors {
+		if e, ok := a.(parser.Element); ok && strings.EqualFold(e.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// https://www.w3.org/TR/wai-aria-1.2/#state_prop_def
+var ariaAttributes = map[string]struct{}{
+	"aria-activedescendant": {}, "aria-atomic": {}, "aria-autocomplete": {}, "aria-braillelabel": {}, "aria-brailleroledescription": {}, "aria-busy": {},
+	"aria-checked": {}, "aria-colcount": {}, "aria-colindex": {}, "aria-colindextext": {}, "aria-colspan": {}, "aria-controls": {}, "aria-current": {},
+	"aria-describedby": {}, "aria-description": {}, "aria-details": {}, "aria-disabled": {}, "aria-dropeffect": {}, "aria-errormessage": {}, "aria-expanded": {},
+	"aria-flowto": {}, "aria-grabbed": {}, "aria-haspopup": {}, "aria-hidden": {}, "aria-invalid": {}, "aria-keyshortcuts": {}, "aria-label": {},
+	"aria-labelledby": {}, "aria-level": {}, "aria-live": {}, "aria-modal": {}, "aria-multiline": {}, "aria-multiselectable": {}, "aria-orientation": {},
+	"aria-owns": {}, "aria-placeholder": {}, "aria-posinset": {}, "aria-pressed": {}, "aria-readonly": {}, "aria-relevant": {}, "aria-required": {},
+	"aria-roledescription": {}, "aria-rowcount": {}, "aria-rowindex": {}, "aria-rowindextext": {}, "aria-rowspan": {}, "aria-selected": {}, "aria-setsize": {},
+	"aria-sort": {}, "aria-valuemax": {}, "aria-valuemin": {}, "aria-valuenow": {}, "aria-valuetext": {},
+}
+
+// https://www.w3.org/TR/wai-aria-1.2/#role_definitions
+var ariaRoles = map[string]struct{}{
+	"alert": {}, "alertdialog": {}, "application": {}, "article": {}, "banner": {}, "blockquote": {}, "button": {}, "caption": {}, "cell": {}, "checkbox": {},
+	"code": {}, "columnheader": {}, "combobox": {}, "complementary": {}, "contentinfo": {}, "definition": {}, "deletion": {}, "dialog": {}, "directory": {},
+	"document": {}, "emphasis": {}, "feed": {}, "figure": {}, "form": {}, "generic": {}, "grid": {}, "gridcell": {}, "group": {}, "heading": {}, "img": {},
+	"insertion": {}, "link": {}, "list": {}, "listbox": {}, "listitem": {}, "log": {}, "main": {}, "marquee": {}, "math": {}, "menu": {}, "menubar": {},
+	"menuitem": {}, "menuitemcheckbox": {}, "menuitemradio": {}, "meter": {}, "navigation": {}, "none": {}, "note": {}, "option": {}, "paragraph": {},
+	"presentation": {}, "progressbar": {}, "radio": {}, "radiogroup": {}, "region": {}, "row": {}, "rowgroup": {}, "rowheader": {}, "scrollbar": {},
+	"search": {}, "searchbox": {}, "separator": {}, "slider": {}, "spinbutton": {}, "status": {}, "strong": {}, "subscript": {}, "superscript": {},
+	"switch": {}, "tab": {}, "table": {}, "tablist": {}, "tabpanel": {}, "term": {}, "textbox": {}, "time": {}, "timer": {}, "toolbar": {}, "tooltip": {},
+	"tree": {}, "treegrid": {}, "treeitem": {},
+}
@@ -0,0 +1,89 @@
+package lintcmd
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAccessibilityRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		expected []string
+	}{
+		{
+			name: "a11y-img-alt: images need an alt attribute, which can be dynamic",
+			template: `templ Page(alt string, ok bool) {
+	<img src="a.png"/>
+	<img src="b.png" alt=""/>
+	<img src="c.png" alt={ alt }/>
+	<img
+		src="d.png"
+		if ok {
+			alt="d"
+		}
+	/>
+}`,
+			expected: []string{"4:2: warning: <img> must have an alt attribute (a11y-img-alt)"},
+		},
+		{
+			name: "a11y-form-label: form controls need a label",
+			template: `templ Form(id string) {
+	<label>Name <input type="text" name="name"/></label>
+	<label for="email">Email</label>
+	<input id="email" type="email"/>
+	<input id="phone" type="tel"/>
+	<input id={ id }/>
+	<textarea aria-label="Comments"></textarea>
+	<select></select>
+	<input type="hidden" name="csrf"/>
+	<input type="submit" value="Send"/>
+}`,
+			expected: []string{
+				"7:2: warning: <input> must have a label (a11y-form-label)",
+				"10:2: warning: <select> must have a label (a11y-form-label)",
+			},
+		},
+		{
+			name: "a11y-aria: aria attributes and roles must be valid",
+			template: `templ Page() {
+	<div role="navigation" aria-label="Main" aria-lable="Main"></div>
+	<div role="buton presentation"></div>
+}`,
+			expected: []string{
+				`4:43: warning: unknown ARIA attribute "aria-lable" (a11y-aria)`,
+				`5:7: warning: unknown ARIA role "buton" (a11y-aria)`,
+			},
+		},
+		{
+			name: "a11y-button-text: buttons need text, unless it might be rendered by an expression",
+			template: `templ Page(label string) {
+	<button><svg><path d="M0"></path></svg></button>
+	<button>Save</button>
+	<button aria-label="Close"><svg></svg></button>
+	<button><img src="x.png" alt="Close"/></button>
+	<button>{ label }</button>
+}`,
+			expected: []string{"4:2: warning: <button> must contain text, or have an aria-label (a11y-button-text)"},
+		},
+		{
+			name: "a11y-tabindex: tabindex must not be greater than zero",
+			template: `templ Page(i string) {
+	<div tabindex="1">A</div>
+	<div tabindex="0">B</div>
+	<div tabindex="-1">C</div>
+	<div tabindex={ i }>D</div>
+}`,
+			expected: []string{"4:7: warning: tabindex should be 0 or -1, not 1 (a11y-tabindex)"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.expected, lintTemplate(t, tt.template)); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package lintcmd
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	parser "github.com/a-h/templ/parser/v2"
+)
+
+// Severity of the diagnostics reported by a rule.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	// SeverityOff disables a rule.
+	SeverityOff Severity = "off"
+)
+
+// ParseSeverity parses a severity from the lint rules in the config file.
+func ParseSeverity(s string) (Severity, error) {
+	switch Severity(s) {
+	case SeverityError, SeverityWarning, SeverityOff:
+		return Severity(s), nil
+	}
+	return SeverityOff, fmt.Errorf("unknown severity %q, expected %q, %q or %q", s, SeverityError, SeverityWarning, SeverityOff)
+}
+
+// Rule checks the templates in a file.
+type Rule struct {
+	// Name of the rule, used to configure its severity, and in disable comments.
+	Name string
+	// Doc describes what the rule checks.
+	Doc string
+	// Severity of the diagnostics that the rule reports.
+	Severity Severity
+	// Run checks a template, and reports problems with Pass.Report.
+	Run func(p *Pass)
+}
+
+// Diagnostic is a problem found by a rule.
+type Diagnostic struct {
+	Rule     string
+	Severity Severity
+	Range    parser.Range
+	Message  string
+}
+
+// Pass is the information passed to a rule to check a template.
+type Pass struct {
+	// File that contains the template.
+	File        parser.TemplateFile
+	Template    parser.HTMLTemplate
+	rule        Rule
+	diagnostics []Diagnostic
+}
+
+// Report a problem with the node at r.
+func (p *Pass) Report(r parser.Range, format string, a ...any) {
+	p.diagnostics = append(p.diagnostics, Diagnostic{
+		Rule:     p.rule.Name,
+		Severity: p.rule.Severity,
+		Range:    r,
+		Message:  fmt.Sprintf(format, a...),
+	})
+}
+
+// Inspect calls f for each node and attribute in the template, in depth-first order,
+// along with the nodes that contain it, outermost first. If f returns false, the
+// children of the node are skipped.
+func (p *Pass) Inspect(f func(node any, ancestors []any) bool) {
+	inspect(p.Template, f)
+}
+
+func inspect(root any, f func(node any, ancestors []any) bool) {
+	var stack []any
+	parser.Inspect(root, func(node any) bool {
+		if node == nil {
+			stack = stack[:len(stack)-1]
+			return false
+		}
+		if !f(node, stack) {
+			return false
+		}
+		stack = append(stack, node)
+		return true
+	})
+}
+
+// Configure returns a copy of the rules with the severities set in the config file,
+// which are keyed by rule name.
+func Configure(rules []Rule, severities map[string]string) (op []Rule, err error) {
+	op = make([]Rule, len(rules))
+	copy(op, rules)
+	for name, s := range severities {
+		i := indexOfRule(op, name)
+		if i < 0 {
+			return nil, fmt.Errorf("unknown lint rule %q", name)
+		}
+		if op[i].Severity, err = ParseSeverity(s); err != nil {
+			return nil, fmt.Errorf("lint rule %q: %w", name, err)
+		}
+	}
+	return op, nil
+}
+
+func indexOfRule(rules []Rule, name string) int {
+	for i, r := range rules {
+		if r.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Lint runs the rules against each template in the file, and returns the diagnostics in
+// the order they appear in the file. Diagnostics are not reported for nodes that follow
+// a disable comment, e.g. // templ-lint-disable duplicate-id.
+func Lint(tf parser.TemplateFile, rules []Rule) (diagnostics []Diagnostic) {
+	for _, n := range tf.Nodes {
+		t, ok := n.(parser.HTMLTemplate)
+		if !ok {
+			continue
+		}
+		disabled := disabledRanges(t)
+		for _, r := range rules {
+			if r.Severity == SeverityOff {
+				continue
+			}
+			p := &Pass{File: tf, Template: t, rule: r}
+			r.Run(p)
+			for _, d := range p.diagnostics {
+				if !disabled.contains(d) {
+					diagnostics = append(diagnostics, d)
+				}
+			}
+		}
+	}
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		return diagnostics[i].Range.From.Index < diagnostics[j].Range.From.Index
+	})
+	return diagnostics
+}
+
+const disableDirective = "templ-lint-disable"
+
+// disabledRange is the range of a node that follows a disable comment. If rules is
+// empty, all rules are disabled.
+type disabledRange struct {
+	rules []string
+	r     parser.Range
+}
+
+type disabledRangeList []disabledRange
+
+func (l disabledRangeList) contains(d Diagnostic) bool {
+	for _, dr := range l {
+		if d.Range.From.Index < dr.r.From.Index || d.Range.From.Index >= dr.r.To.Index {
+			continue
+		}
+		if len(dr.rules) == 0 {
+			return true
+		}
+		for _, name := range dr.rules {
+			if name == d.Rule {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// disabledRanges finds the templ comments that disable rules, and returns the ranges of
+// the nodes that follow them.
+func disabledRanges(t parser.HTMLTemplate) (op disabledRangeList) {
+	var pending *disabledRange
+	var pendingDepth int
+	inspect(t, func(node any, ancestors []any) bool {
+		if _, isWhitespace := node.(parser.Whitespace); isWhitespace {
+			return true
+		}
+		if pending != nil && len(ancestors) == pendingDepth {
+			pending.r = rangeOf(node)
+			op = append(op, *pending)
+		}
+		pending = nil
+		if c, isComment := node.(parser.Comment); isComment && c.Type != parser.CommentTypeHTML {
+			if rules, ok := parseDisableComment(c.Contents); ok {
+				pending, pendingDepth = &disabledRange{rules: rules}, len(ancestors)
+			}
+		}
+		return true
+	})
+	return op
+}
+
+// parseDisableComment parses the rule names from a comment such as
+// "templ-lint-disable invalid-nesting, duplicate-id".
+func parseDisableComment(s string) (rules []string, ok bool) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, disableDirective) {
+		return nil, false
+	}
+	s = strings.TrimPrefix(s, disableDirective)
+	if s != "" && s[0] != ' ' && s[0] != '\t' {
+		return nil, false
+	}
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == ','
+	}), true
+}
+
+// rangeOf returns the Range field of a node or attribute.
+func rangeOf(node any) parser.Range {
+	v := reflect.ValueOf(node)
+	if v.Kind() != reflect.Struct {
+		return parser.Range{}
+	}
+	if f := v.FieldByName("Range"); f.IsValid() {
+		if r, ok := f.Interface().(parser.Range); ok {
+			return r
+		}
+	}
+	return parser.Range{}
+}
@@ -0,0 +1,192 @@
+package lintcmd
+
+import (
+	"fmt"
+	"testing"
+
+	parser "github.com/a-h/templ/parser/v2"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		expected []string
+	}{
+		{
+			name: "valid templates have no diagnostics",
+			template: `templ Page(id string) {
+	<p class="intro">Hello, <a href="/">home</a></p>
+	<div id={ id }>
+		<br/>
+	</div>
+}`,
+			expected: nil,
+		},
+		{
+			name: "invalid-nesting: block elements can't be inside a paragraph",
+			template: `templ Page() {
+	<p>
+		<span><div>Text</div></span>
+	</p>
+}`,
+			expected: []string{"5:9: error: <div> cannot be inside <p> (invalid-nesting)"},
+		},
+		{
+			name: "invalid-nesting: links can't be inside links, even in an if",
+			template: `templ Page(ok bool) {
+	<a href="/">
+		if ok {
+			<a href="/b">B</a>
+		}
+	</a>
+}`,
+			expected: []string{"6:4: error: <a> cannot be inside another <a> (invalid-nesting)"},
+		},
+		{
+			name: "duplicate-id: constant ids are reported",
+			template: `templ Page() {
+	<div id="a"></div>
+	<div id="a"></div>
+}`,
+			expected: []string{`5:7: error: duplicate id "a", first used on line 4 (duplicate-id)`},
+		},
+		{
+			name: "duplicate-id: ids in different branches of an if or switch are allowed",
+			template: `templ Page(ok bool, n int) {
+	if ok {
+		<div id="a"></div>
+	} else if n > 1 {
+		<div id="a"></div>
+	} else {
+		<div id="a"></div>
+	}
+	switch n {
+		case 1:
+			<div id="b"></div>
+		case 2:
+			<div id="b"></div>
+	}
+}`,
+			expected: nil,
+		},
+		{
+			name: "duplicate-id: ids inside and outside an if are reported",
+			template: `templ Page(ok bool) {
+	<div id="a"></div>
+	if ok {
+		<div id="a"></div>
+	}
+}`,
+			expected: []string{`6:8: error: duplicate id "a", first used on line 4 (duplicate-id)`},
+		},
+		{
+			name: "duplicate-id: ids in different branches of a conditional attribute are allowed",
+			template: `templ Page(n int) {
+	<hr
+		if n == 1 {
+			id="a"
+		} else if n == 2 {
+			id="a"
+		} else {
+			id="a"
+		}
+	/>
+}`,
+			expected: nil,
+		},
+		{
+			name: "void-element-children: void elements can't have children",
+			template: `templ Page() {
+	<br>text</br>
+}`,
+			expected: []string{"4:2: error: <br> is a void element, and cannot have children (void-element-children)"},
+		},
+		{
+			name: "deprecated-element: obsolete elements are reported",
+			template: `templ Page() {
+	<center>Text</center>
+}`,
+			expected: []string{"4:2: warning: <center> is deprecated, use CSS instead (deprecated-element)"},
+		},
+		{
+			name: "misspelled-attribute: attributes close to a known attribute are reported",
+			template: `templ Page() {
+	<a herf="/" clas="link" hx-get="/a" data-test="a" onclik={ templ.ComponentScript{} }>Link</a>
+	<my-element clas="a"></my-element>
+	<svg viewBox="0 0 10 10"><path d="M0"></path></svg>
+}`,
+			expected: []string{
+				`4:5: warning: unknown attribute "herf", did you mean "href"? (misspelled-attribute)`,
+				`4:14: warning: unknown attribute "clas", did you mean "class"? (misspelled-attribute)`,
+				`4:52: warning: unknown attribute "onclik", did you mean "onclick"? (misspelled-attribute)`,
+			},
+		},
+		{
+			name: "disable comments apply to the next node",
+			template: `templ Page() {
+	// templ-lint-disable deprecated-element
+	<center><font>Text</font></center>
+	<font>Text</font>
+	/* templ-lint-disable */
+	<p><div id="a"></div></p>
+}`,
+			expected: []string{"6:2: warning: <font> is deprecated, use CSS instead (deprecated-element)"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.expected, lintTemplate(t, tt.template)); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+// lintTemplate runs the default rules against the template, and returns the diagnostics
+// formatted as line:col: severity: message (rule).
+func lintTemplate(t *testing.T, template string) (diagnostics []string) {
+	t.Helper()
+	tf, err := parser.ParseString("package main\n\n" + template)
+	if err != nil {
+		t.Fatalf("failed to parse template: %v", err)
+	}
+	for _, d := range Lint(tf, DefaultRules) {
+		diagnostics = append(diagnostics, fmt.Sprintf("%d:%d: %s: %s (%s)", d.Range.From.Line+1, d.Range.From.Col+1, d.Severity, d.Message, d.Rule))
+	}
+	return diagnostics
+}
+
+func TestConfigure(t *testing.T) {
+	rules, err := Configure(DefaultRules, map[string]string{
+		"deprecated-element": "error",
+		"duplicate-id":       "off",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tf, err := parser.ParseString(`package main
+
+templ Page() {
+	<div id="a"><center></center></div>
+	<div id="a"></div>
+}`)
+	if err != nil {
+		t.Fatalf("failed to parse template: %v", err)
+	}
+	diagnostics := Lint(tf, rules)
+	if len(diagnostics) != 1 || diagnostics[0].Rule != "deprecated-element" || diagnostics[0].Severity != SeverityError {
+		t.Errorf("expected a single deprecated-element error, got %v", diagnostics)
+	}
+	if DeprecatedElement.Severity != SeverityWarning {
+		t.Error("expected the default rules not to be changed")
+	}
+	if _, err = Configure(DefaultRules, map[string]string{"unknown": "error"}); err == nil {
+		t.Error("expected an error for an unknown rule")
+	}
+	if _, err = Configure(DefaultRules, map[string]string{"duplicate-id": "fatal"}); err == nil {
+		t.Error("expected an error for an unknown severity")
+	}
+}
@@ -0,0 +1,100 @@
+package lintcmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/a-h/templ/cmd/templ/config"
+	"github.com/a-h/templ/cmd/templ/output"
+	"github.com/a-h/templ/cmd/templ/processor"
+	parser "github.com/a-h/templ/parser/v2"
+)
+
+const workerCount = 4
+
+type Arguments struct {
+	// Paths of files or directories to lint. If there are none, the current directory is linted.
+	Paths []string
+	// LogFormat is the format of diagnostics and errors written to stdout, text by default.
+	LogFormat output.Format
+}
+
+// Run lints the templates in the paths, and writes the diagnostics. It returns an error if
+// any files couldn't be parsed, or if there are diagnostics with error severity.
+func Run(args Arguments) (err error) {
+	paths := args.Paths
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	log := output.New(os.Stdout, args.LogFormat)
+	start := time.Now()
+	var c counts
+	f := func(fileName string) error {
+		return lint(log, &c, fileName)
+	}
+	results := make(chan processor.Result)
+	go func() {
+		defer close(results)
+		for _, path := range paths {
+			pathResults := make(chan processor.Result)
+			go processor.Process(path, f, workerCount, pathResults)
+			for r := range pathResults {
+				results <- r
+			}
+		}
+	}()
+	var fileCount, errorCount int
+	for r := range results {
+		fileCount++
+		if r.Error != nil {
+			log.File(r.FileName, r.Duration, r.Error)
+			err = errors.Join(err, fmt.Errorf("%s: %w", r.FileName, r.Error))
+			errorCount++
+		}
+	}
+	errs, warnings := c.errors.Load(), c.warnings.Load()
+	log.Summary(fmt.Sprintf("Linted %d templates with %d errors and %d warnings in %s", fileCount, errs, warnings, time.Since(start)), fileCount, errorCount, time.Since(start))
+	err = output.Reported(err)
+	if errs > 0 {
+		err = errors.Join(err, fmt.Errorf("found %d lint errors", errs))
+	}
+	return err
+}
+
+// lint writes the diagnostics for a file to the log, and adds them to the counts.
+func lint(log *output.Writer, c *counts, fileName string) (err error) {
+	src, err := os.ReadFile(fileName)
+	if err != nil {
+		return fmt.Errorf("%s read file error: %w", fileName, err)
+	}
+	tf, err := parser.ParseString(string(src))
+	if err != nil {
+		return fmt.Errorf("%s parsing error: %w", fileName, err)
+	}
+	cfg, _, err := config.Load(filepath.Dir(fileName))
+	if err != nil {
+		return err
+	}
+	rules, err := Configure(DefaultRules, cfg.Lint.Rules)
+	if err != nil {
+		return err
+	}
+	for _, d := range Lint(tf, rules) {
+		log.Diagnostic(fileName, int(d.Range.From.Line), int(d.Range.From.Col), string(d.Severity), fmt.Sprintf("%s (%s)", d.Message, d.Rule))
+		if d.Severity == SeverityError {
+			c.errors.Add(1)
+		} else {
+			c.warnings.Add(1)
+		}
+	}
+	return nil
+}
+
+// counts of the diagnostics found in all files.
+type counts struct {
+	errors, warnings atomic.Int64
+}
@@ -0,0 +1,326 @@
+package lintcmd
+
+import (
+	"strings"
+
+	parser "github.com/a-h/templ/parser/v2"
+)
+
+// DefaultRules are the rules run by templ lint, with their default severities.
+var DefaultRules = []Rule{
+	InvalidNesting,
+	DuplicateID,
+	VoidElementChildren,
+	DeprecatedElement,
+	MisspelledAttribute,
+	ImageAlt,
+	FormLabel,
+	ARIA,
+	ButtonText,
+	PositiveTabIndex,
+}
+
+var InvalidNesting = Rule{
+	Name:     "invalid-nesting",
+	Doc:      "Reports elements that browsers move out of their parent, such as a <div> inside a <p>, or an <a> inside an <a>.",
+	Severity: SeverityError,
+	Run: func(p *Pass) {
+		p.Inspect(func(node any, ancestors []any) bool {
+			e, ok := node.(parser.Element)
+			if !ok {
+				return true
+			}
+			name := strings.ToLower(e.Name)
+			for _, a := range ancestors {
+				parent, ok := a.(parser.Element)
+				if !ok {
+					continue
+				}
+				parentName := strings.ToLower(parent.Name)
+				if _, closesParagraph := closesParagraph[name]; closesParagraph && parentName == "p" {
+					p.Report(e.Range, "<%s> cannot be inside <p>", e.Name)
+					break
+				}
+				if _, notNestable := notNestable[name]; notNestable && parentName == name {
+					p.Report(e.Range, "<%s> cannot be inside another <%s>", e.Name, e.Name)
+					break
+				}
+			}
+			return true
+		})
+	},
+}
+
+// Elements that close an open <p>.
+// https://html.spec.whatwg.org/multipage/grouping-content.html#the-p-element
+var closesParagraph = map[string]struct{}{
+	"address": {}, "article": {}, "aside": {}, "blockquote": {}, "details": {}, "dialog": {}, "div": {}, "dl": {}, "fieldset": {}, "figcaption": {}, "figure": {}, "footer": {}, "form": {}, "h1": {}, "h2": {}, "h3": {}, "h4": {}, "h5": {}, "h6": {}, "header": {}, "hgroup": {}, "hr": {}, "main": {}, "menu": {}, "nav": {}, "ol": {}, "p": {}, "pre": {}, "search": {}, "section": {}, "table": {}, "ul": {},
+}
+
+// Elements that can't contain themselves.
+var notNestable = map[string]struct{}{
+	"a": {}, "button": {}, "form": {}, "label": {},
+}
+
+var DuplicateID = Rule{
+	Name:     "duplicate-id",
+	Doc:      "Reports id attributes with the same constant value in a template. Elements in different branches of an if or switch can share an id.",
+	Severity: SeverityError,
+	Run: func(p *Pass) {
+		type use struct {
+			r        parser.Range
+			branches map[int64]int
+		}
+		uses := map[string][]use{}
+		p.Inspect(func(node any, ancestors []any) bool {
+			attr, ok := node.(parser.ConstantAttribute)
+			if !ok || !strings.EqualFold(attr.Name, "id") {
+				return true
+			}
+			u := use{r: attr.Range, branches: branchesOf(attr.Range, ancestors)}
+			for _, prev := range uses[attr.Value] {
+				if !exclusive(prev.branches, u.branches) {
+					p.Report(attr.Range, "duplicate id %q, first used on line %d", attr.Value, prev.r.From.Line+1)
+					break
+				}
+			}
+			uses[attr.Value] = append(uses[attr.Value], u)
+			return true
+		})
+	},
+}
+
+// branchesOf returns the branch of each if, switch and conditional attribute that the
+// node at r is in, keyed by the start index of the if, switch or conditional attribute.
+func branchesOf(r parser.Range, ancestors []any) map[int64]int {
+	branches := map[int64]int{}
+	for _, a := range ancestors {
+		switch n := a.(type) {
+		case parser.IfExpression:
+			branches[n.Range.From.Index] = ifBranch(r, n.ElseIfs, n.Else)
+		case parser.ConditionalAttribute:
+			branches[n.Range.From.Index] = ifBranch(r, n.ElseIfs, n.Else)
+		case parser.SwitchExpression:
+			for i, c := range n.Cases {
+				if r.From.Index >= c.Range.From.Index && r.From.Index < c.Range.To.Index {
+					branches[n.Range.From.Index] = i
+				}
+			}
+		}
+	}
+	return branches
+}
+
+// ifBranch returns the index of the branch of an if that contains r, where 0 is the then
+// branch, followed by each else if, and then the else branch.
+func ifBranch[TElseIf, TElse any](r parser.Range, elseIfs []TElseIf, elseBranch []TElse) (i int) {
+	starts := make([]int64, 0, len(elseIfs)+1)
+	for _, elseIf := range elseIfs {
+		starts = append(starts, rangeOf(elseIf).From.Index)
+	}
+	if len(elseBranch) > 0 {
+		starts = append(starts, rangeOf(elseBranch[0]).From.Index)
+	}
+	for _, start := range starts {
+		if r.From.Index < start {
+			break
+		}
+		i++
+	}
+	return i
+}
+
+// exclusive returns true if the branches are of the same if or switch, but can't
+// both be rendered.
+func exclusive(a, b map[int64]int) bool {
+	for k, v := range a {
+		if w, ok := b[k]; ok && v != w {
+			return true
+		}
+	}
+	return false
+}
+
+var VoidElementChildren = Rule{
+	Name:     "void-element-children",
+	Doc:      "Reports void elements, such as <br> and <img>, that have children.",
+	Severity: SeverityError,
+	Run: func(p *Pass) {
+		p.Inspect(func(node any, ancestors []any) bool {
+			e, ok := node.(parser.Element)
+			if !ok || !e.IsVoidElement() {
+				return true
+			}
+			for _, c := range e.Children {
+				if _, isWhitespace := c.(parser.Whitespace); !isWhitespace {
+					p.Report(e.Range, "<%s> is a void element, and cannot have children", e.Name)
+					break
+				}
+			}
+			return true
+		})
+	},
+}
+
+var DeprecatedElement = Rule{
+	Name:     "deprecated-element",
+	Doc:      "Reports elements that are obsolete in HTML5, such as <center> and <font>.",
+	Severity: SeverityWarning,
+	Run: func(p *Pass) {
+		p.Inspect(func(node any, ancestors []any) bool {
+			e, ok := node.(parser.Element)
+			if !ok {
+				return true
+			}
+			alternative, deprecated := deprecatedElements[strings.ToLower(e.Name)]
+			if !deprecated {
+				return true
+			}
+			if alternative != "" {
+				p.Report(e.Range, "<%s> is deprecated, use %s instead", e.Name, alternative)
+				return true
+			}
+			p.Report(e.Range, "<%s> is deprecated", e.Name)
+			return true
+		})
+	},
+}
+
+// Obsolete elements, and what to use instead.
+// https://html.spec.whatwg.org/multipage/obsolete.html#non-conforming-features
+var deprecatedElements = map[string]string{
+	"acronym":   "<abbr>",
+	"applet":    "<object>",
+	"basefont":  "CSS",
+	"big":       "CSS",
+	"blink":     "CSS",
+	"center":    "CSS",
+	"dir":       "<ul>",
+	"font":      "CSS",
+	"frame":     "<iframe>",
+	"frameset":  "<iframe>",
+	"isindex":   "<form>",
+	"listing":   "<pre>",
+	"marquee":   "CSS",
+	"nobr":      "CSS",
+	"noframes":  "",
+	"plaintext": "<pre>",
+	"spacer":    "CSS",
+	"strike":    "<s> or <del>",
+	"tt":        "<code> or <kbd>",
+	"xmp":       "<pre>",
+}
+
+var MisspelledAttribute = Rule{
+	Name:     "misspelled-attribute",
+	Doc:      "Reports attributes that aren't HTML attributes, but are one letter away from one, such as clas or herf.",
+	Severity: SeverityWarning,
+	Run: func(p *Pass) {
+		p.Inspect(func(node any, ancestors []any) bool {
+			if e, ok := node.(parser.Element); ok {
+				// SVG and MathML have their own attributes.
+				name := strings.ToLower(e.Name)
+				return name != "svg" && name != "math"
+			}
+			name, r, ok := attributeName(node)
+			if !ok || isCustomElement(ancestors) {
+				return true
+			}
+			name = strings.ToLower(name)
+			// Short names are too likely to be close to a real attribute by chance, and names
+			// with punctuation are used by frameworks, e.g. data-*, hx-get and x-on:click.
+			if len(name) < 4 || strings.ContainsAny(name, "-:.@_") {
+				return true
+			}
+			if _, known := knownAttributes[name]; known {
+				return true
+			}
+			var suggestion string
+			for known := range knownAttributes {
+				if editDistanceIsOne(name, known) && (suggestion == "" || known < suggestion) {
+					suggestion = known
+				}
+			}
+			if suggestion != "" {
+				p.Report(r, "unknown attribute %q, did you mean %q?", name, suggestion)
+			}
+			return true
+		})
+	},
+}
+
+// isCustomElement returns true if the innermost element is a custom element, which
+// can have any attributes.
+func isCustomElement(ancestors []any) bool {
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		if e, ok := ancestors[i].(parser.Element); ok {
+			return strings.Contains(e.Name, "-")
+		}
+	}
+	return false
+}
+
+func attributeName(node any) (name string, r parser.Range, ok bool) {
+	switch a := node.(type) {
+	case parser.BoolConstantAttribute:
+		return a.Name, a.Range, true
+	case parser.ConstantAttribute:
+		return a.Name, a.Range, true
+	case parser.BoolExpressionAttribute:
+		return a.Name, a.Range, true
+	case parser.ExpressionAttribute:
+		return a.Name, a.Range, true
+	case parser.InterpolatedAttribute:
+		return a.Name, a.Range, true
+	}
+	return "", parser.Range{}, false
+}
+
+// editDistanceIsOne returns true if a can be changed to b by inserting, deleting or
+// changing one letter, or swapping two adjacent letters.
+func editDistanceIsOne(a, b string) bool {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	switch len(a) - len(b) {
+	case 0:
+		var diffs []int
+		for i := 0; i < len(a); i++ {
+			if a[i] != b[i] {
+				diffs = append(diffs, i)
+			}
+		}
+		if len(diffs) == 1 {
+			return true
+		}
+		return len(diffs) == 2 && diffs[1] == diffs[0]+1 && a[diffs[0]] == b[diffs[1]] && a[diffs[1]] == b[diffs[0]]
+	case 1:
+		// a is one letter longer than b, so remove the first letter that differs.
+		for i := 0; i < len(b); i++ {
+			if a[i] != b[i] {
+				return a[i+1:] == b[i:]
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// Global attributes, element attributes and event handler attributes.
+// https://html.spec.whatwg.org/multipage/indices.html#attributes-3
+var knownAttributes = map[string]struct{}{
+	"abbr": {}, "accept": {}, "accesskey": {}, "action": {}, "align": {}, "allow": {}, "allowfullscreen": {}, "alt": {}, "async": {}, "autocapitalize": {}, "autocomplete": {}, "autofocus": {}, "autoplay": {},
+	"background": {}, "bgcolor": {}, "border": {}, "capture": {}, "charset": {}, "checked": {}, "cite": {}, "class": {}, "color": {}, "cols": {}, "colspan": {}, "content": {}, "contenteditable": {}, "controls": {}, "coords": {}, "crossorigin": {},
+	"data": {}, "datetime": {}, "decoding": {}, "default": {}, "defer": {}, "dirname": {}, "disabled": {}, "download": {}, "draggable": {}, "enctype": {}, "enterkeyhint": {},
+	"form": {}, "formaction": {}, "formenctype": {}, "formmethod": {}, "formnovalidate": {}, "formtarget": {}, "headers": {}, "height": {}, "hidden": {}, "high": {}, "href": {}, "hreflang": {},
+	"inert": {}, "inputmode": {}, "integrity": {}, "ismap": {}, "itemid": {}, "itemprop": {}, "itemref": {}, "itemscope": {}, "itemtype": {}, "kind": {}, "label": {}, "lang": {}, "list": {}, "loading": {}, "loop": {}, "low": {},
+	"manifest": {}, "max": {}, "maxlength": {}, "media": {}, "method": {}, "min": {}, "minlength": {}, "multiple": {}, "muted": {}, "name": {}, "nomodule": {}, "nonce": {}, "novalidate": {},
+	"open": {}, "optimum": {}, "pattern": {}, "ping": {}, "placeholder": {}, "playsinline": {}, "popover": {}, "popovertarget": {}, "popovertargetaction": {}, "poster": {}, "preload": {},
+	"readonly": {}, "referrerpolicy": {}, "rel": {}, "required": {}, "reversed": {}, "role": {}, "rows": {}, "rowspan": {},
+	"sandbox": {}, "scope": {}, "selected": {}, "shape": {}, "size": {}, "sizes": {}, "slot": {}, "span": {}, "spellcheck": {}, "src": {}, "srcdoc": {}, "srclang": {}, "srcset": {}, "start": {}, "step": {}, "style": {}, "summary": {},
+	"tabindex": {}, "target": {}, "title": {}, "translate": {}, "type": {}, "usemap": {}, "value": {}, "width": {}, "wrap": {},
+	"onabort": {}, "onafterprint": {}, "onbeforeprint": {}, "onbeforeunload": {}, "onblur": {}, "oncancel": {}, "oncanplay": {}, "onchange": {}, "onclick": {}, "onclose": {}, "oncontextmenu": {}, "oncopy": {}, "oncut": {},
+	"ondblclick": {}, "ondrag": {}, "ondragend": {}, "ondragenter": {}, "ondragleave": {}, "ondragover": {}, "ondragstart": {}, "ondrop": {}, "onended": {}, "onerror": {}, "onfocus": {}, "onhashchange": {}, "oninput": {}, "oninvalid": {},
+	"onkeydown": {}, "onkeypress": {}, "onkeyup": {}, "onload": {}, "onmessage": {}, "onmousedown": {}, "onmouseenter": {}, "onmouseleave": {}, "onmousemove": {}, "onmouseout": {}, "onmouseover": {}, "onmouseup": {},
+	"onpaste": {}, "onpause": {}, "onplay": {}, "onpointerdown": {}, "onpointermove": {}, "onpointerup": {}, "onpopstate": {}, "onreset": {}, "onresize": {}, "onscroll": {}, "onselect": {}, "onsubmit": {}, "ontoggle": {}, "onunload": {}, "onwheel": {},
+}
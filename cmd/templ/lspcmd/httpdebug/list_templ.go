@@ -100,7 +100,11 @@ func list(uris []string) templ.Component {
 				return err
 			}
 			// StringExpression
-			var var_3 string = uri
+			var var_3 string
+			var_3, err = templ.ToString(uri)
+			if err != nil {
+				return err
+			}
 			_, err = templBuffer.WriteString(templ.EscapeString(var_3))
 			if err != nil {
 				return err
@@ -1,7 +1,9 @@
 package proxy
 
 import (
+	"net/url"
 	"path"
+	"path/filepath"
 	"strings"
 
 	lsp "github.com/a-h/protocol"
@@ -22,3 +24,13 @@ func convertTemplGoToTemplURI(goURI lsp.DocumentURI) (isTemplGoFile bool, templU
 	}
 	return true, lsp.DocumentURI(base + (strings.TrimSuffix(fileName, "_templ.go") + ".templ"))
 }
+
+// convertTemplURIToFileName returns the local file path of the templ file, or an empty
+// string if the URI isn't a file URI.
+func convertTemplURIToFileName(templURI lsp.DocumentURI) (fileName string) {
+	u, err := url.Parse(string(templURI))
+	if err != nil || u.Scheme != "file" {
+		return ""
+	}
+	return filepath.FromSlash(u.Path)
+}
@@ -3,11 +3,16 @@ package proxy
 import (
 	"context"
 	"fmt"
+	"io"
+	"path/filepath"
 	"strings"
 
 	"github.com/a-h/parse"
 	lsp "github.com/a-h/protocol"
+	"github.com/a-h/templ/cmd/templ/config"
+	"github.com/a-h/templ/cmd/templ/lintcmd"
 	"github.com/a-h/templ/generator"
+	"github.com/a-h/templ/generator/imports"
 	"github.com/a-h/templ/parser/v2"
 	"go.lsp.dev/uri"
 	"go.uber.org/zap"
@@ -34,6 +39,8 @@ type Server struct {
 	SourceMapCache *SourceMapCache
 	TemplSource    *DocumentContents
 	GoSource       map[string]string
+	// Resolvers find missing imports, and are shared between the templ files in a package.
+	Resolvers *imports.ResolverCache
 }
 
 func NewServer(log *zap.Logger, target lsp.Server, cache *SourceMapCache) (s *Server, init func(lsp.Client)) {
@@ -43,6 +50,7 @@ func NewServer(log *zap.Logger, target lsp.Server, cache *SourceMapCache) (s *Se
 		SourceMapCache: cache,
 		TemplSource:    newDocumentContents(log),
 		GoSource:       make(map[string]string),
+		Resolvers:      imports.NewResolverCache(),
 	}
 	return s, func(client lsp.Client) {
 		s.Client = client
@@ -115,22 +123,40 @@ func (p *Server) convertGoRangeToTemplRange(templURI lsp.DocumentURI, input lsp.
 }
 
 // parseTemplate parses the templ file content, and notifies the end user via the LSP about how it went.
-func (p *Server) parseTemplate(ctx context.Context, uri uri.URI, templateText string) (template parser.TemplateFile, ok bool, err error) {
+// If the parser recovered from the errors it found, ok and recovered are true, and the template
+// contains the valid parts of the file, so that features like hover keep working.
+func (p *Server) parseTemplate(ctx context.Context, uri uri.URI, templateText string) (template parser.TemplateFile, ok, recovered bool, err error) {
 	template, err = parser.ParseString(templateText)
-	if err != nil {
-		msg := &lsp.PublishDiagnosticsParams{
-			URI: uri,
-			Diagnostics: []lsp.Diagnostic{
-				{
-					Severity: lsp.DiagnosticSeverityError,
-					Code:     "",
-					Source:   "templ",
-					Message:  err.Error(),
+	diagnostics := []lsp.Diagnostic{}
+	if errs, isRecovered := err.(parser.Errors); isRecovered {
+		for _, e := range errs {
+			diagnostics = append(diagnostics, lsp.Diagnostic{
+				Severity: lsp.DiagnosticSeverityError,
+				Code:     "",
+				Source:   "templ",
+				Message:  e.Msg,
+				Range: lsp.Range{
+					Start: lsp.Position{
+						Line:      e.Range.From.Line,
+						Character: e.Range.From.Col,
+					},
+					End: lsp.Position{
+						Line:      e.Range.To.Line,
+						Character: e.Range.To.Col,
+					},
 				},
-			},
+			})
+		}
+		ok, recovered, err = true, true, nil
+	} else if err != nil {
+		diagnostic := lsp.Diagnostic{
+			Severity: lsp.DiagnosticSeverityError,
+			Code:     "",
+			Source:   "templ",
+			Message:  err.Error(),
 		}
 		if pe, isParserError := err.(parse.ParseError); isParserError {
-			msg.Diagnostics[0].Range = lsp.Range{
+			diagnostic.Range = lsp.Range{
 				Start: lsp.Position{
 					Line:      uint32(pe.Pos.Line),
 					Character: uint32(pe.Pos.Col),
@@ -141,17 +167,17 @@ func (p *Server) parseTemplate(ctx context.Context, uri uri.URI, templateText st
 				},
 			}
 		}
-		err = p.Client.PublishDiagnostics(ctx, msg)
-		if err != nil {
-			p.Log.Error("failed to publish error diagnostics", zap.Error(err))
-		}
-		return
+		diagnostics = append(diagnostics, diagnostic)
+	} else {
+		ok = true
+	}
+	if ok {
+		diagnostics = append(diagnostics, p.lint(uri, template)...)
 	}
-	ok = true
-	// Clear diagnostics.
+	// Publishing an empty list clears the diagnostics.
 	err = p.Client.PublishDiagnostics(ctx, &lsp.PublishDiagnosticsParams{
 		URI:         uri,
-		Diagnostics: []lsp.Diagnostic{},
+		Diagnostics: diagnostics,
 	})
 	if err != nil {
 		p.Log.Error("failed to publish diagnostics", zap.Error(err))
@@ -160,6 +186,44 @@ func (p *Server) parseTemplate(ctx context.Context, uri uri.URI, templateText st
 	return
 }
 
+// lint returns the diagnostics of the lint rules, with the severities set in the config.
+func (p *Server) lint(uri uri.URI, template parser.TemplateFile) (diagnostics []lsp.Diagnostic) {
+	rules := lintcmd.DefaultRules
+	if fileName := convertTemplURIToFileName(uri); fileName != "" {
+		c, _, err := config.Load(filepath.Dir(fileName))
+		if err == nil {
+			rules, err = lintcmd.Configure(rules, c.Lint.Rules)
+		}
+		if err != nil {
+			p.Log.Warn("lint: failed to load config, using default rules", zap.Error(err))
+			rules = lintcmd.DefaultRules
+		}
+	}
+	for _, d := range lintcmd.Lint(template, rules) {
+		severity := lsp.DiagnosticSeverityWarning
+		if d.Severity == lintcmd.SeverityError {
+			severity = lsp.DiagnosticSeverityError
+		}
+		diagnostics = append(diagnostics, lsp.Diagnostic{
+			Severity: severity,
+			Code:     d.Rule,
+			Source:   "templ lint",
+			Message:  d.Message,
+			Range: lsp.Range{
+				Start: lsp.Position{
+					Line:      d.Range.From.Line,
+					Character: d.Range.From.Col,
+				},
+				End: lsp.Position{
+					Line:      d.Range.To.Line,
+					Character: d.Range.To.Col,
+				},
+			},
+		})
+	}
+	return diagnostics
+}
+
 func (p *Server) Initialize(ctx context.Context, params *lsp.InitializeParams) (result *lsp.InitializeResult, err error) {
 	p.Log.Info("client -> server: Initialize")
 	defer p.Log.Info("client -> server: Initialize end")
@@ -406,7 +470,7 @@ func (p *Server) DidChange(ctx context.Context, params *lsp.DidChangeTextDocumen
 	}
 	// Update the Go code.
 	p.Log.Info("parsing template")
-	template, ok, err := p.parseTemplate(ctx, params.TextDocument.URI, d.String())
+	template, ok, _, err := p.parseTemplate(ctx, params.TextDocument.URI, d.String())
 	if err != nil {
 		p.Log.Error("parseTemplate failure", zap.Error(err))
 	}
@@ -414,7 +478,7 @@ func (p *Server) DidChange(ctx context.Context, params *lsp.DidChangeTextDocumen
 		return
 	}
 	w := new(strings.Builder)
-	sm, err := generator.Generate(template, w)
+	sm, err := p.generate(params.TextDocument.URI, template, w)
 	if err != nil {
 		p.Log.Error("generate failure", zap.Error(err))
 		return
@@ -442,6 +506,8 @@ func (p *Server) DidChangeConfiguration(ctx context.Context, params *lsp.DidChan
 func (p *Server) DidChangeWatchedFiles(ctx context.Context, params *lsp.DidChangeWatchedFilesParams) (err error) {
 	p.Log.Info("client -> server: DidChangeWatchedFiles")
 	defer p.Log.Info("client -> server: DidChangeWatchedFiles end")
+	// Packages may have been added or changed, so imports need to be resolved again.
+	p.Resolvers.Clear()
 	return p.Target.DidChangeWatchedFiles(ctx, params)
 }
 
@@ -476,7 +542,7 @@ func (p *Server) DidOpen(ctx context.Context, params *lsp.DidOpenTextDocumentPar
 	// Cache the template doc.
 	p.TemplSource.Set(string(params.TextDocument.URI), NewDocument(p.Log, params.TextDocument.Text))
 	// Parse the template.
-	template, ok, err := p.parseTemplate(ctx, params.TextDocument.URI, params.TextDocument.Text)
+	template, ok, _, err := p.parseTemplate(ctx, params.TextDocument.URI, params.TextDocument.Text)
 	if err != nil {
 		p.Log.Error("parseTemplate failure", zap.Error(err))
 	}
@@ -487,7 +553,7 @@ func (p *Server) DidOpen(ctx context.Context, params *lsp.DidOpenTextDocumentPar
 	// Generate the output code and cache the source map and Go contents to use during completion
 	// requests.
 	w := new(strings.Builder)
-	sm, err := generator.Generate(template, w)
+	sm, err := p.generate(params.TextDocument.URI, template, w)
 	if err != nil {
 		return
 	}
@@ -504,12 +570,22 @@ func (p *Server) DidOpen(ctx context.Context, params *lsp.DidOpenTextDocumentPar
 func (p *Server) DidSave(ctx context.Context, params *lsp.DidSaveTextDocumentParams) (err error) {
 	p.Log.Info("client -> server: DidSave")
 	defer p.Log.Info("client -> server: DidSave end")
-	if isTemplFile, goURI := convertTemplToGoURI(params.TextDocument.URI); isTemplFile {
-		params.TextDocument.URI = goURI
+	isTemplFile, goURI := convertTemplToGoURI(params.TextDocument.URI)
+	if !isTemplFile {
+		// The declarations in the package may have changed.
+		p.Resolvers.Delete(convertTemplURIToFileName(params.TextDocument.URI))
+		return p.Target.DidSave(ctx, params)
 	}
+	params.TextDocument.URI = goURI
 	return p.Target.DidSave(ctx, params)
 }
 
+// generate Go code from the template, using the import resolver of the template's package.
+func (p *Server) generate(templURI lsp.DocumentURI, template parser.TemplateFile, w io.Writer) (*parser.SourceMap, error) {
+	fileName := convertTemplURIToFileName(templURI)
+	return generator.Generate(template, w, generator.WithFileName(fileName), generator.WithResolver(p.Resolvers.Get(fileName)))
+}
+
 func (p *Server) DocumentColor(ctx context.Context, params *lsp.DocumentColorParams) (result []lsp.ColorInformation, err error) {
 	p.Log.Info("client -> server: DocumentColor")
 	defer p.Log.Info("client -> server: DocumentColor end")
@@ -610,15 +686,27 @@ func (p *Server) Formatting(ctx context.Context, params *lsp.DocumentFormattingP
 	defer p.Log.Info("client -> server: Formatting end")
 	// Format the current document.
 	d, _ := p.TemplSource.Get(string(params.TextDocument.URI))
-	template, ok, err := p.parseTemplate(ctx, params.TextDocument.URI, d.String())
+	template, ok, recovered, err := p.parseTemplate(ctx, params.TextDocument.URI, d.String())
 	if err != nil {
 		p.Log.Error("parseTemplate failure", zap.Error(err))
 	}
-	if !ok {
+	// A recovered template is missing the parts of the file that couldn't be parsed, so
+	// formatting it would delete them from the document.
+	if !ok || recovered {
 		return
 	}
+	opts := parser.DefaultFormatOptions()
+	if fileName := convertTemplURIToFileName(params.TextDocument.URI); fileName != "" {
+		c, _, err := config.Load(filepath.Dir(fileName))
+		if err != nil {
+			p.Log.Warn("handleFormatting: failed to load config, using defaults", zap.Error(err))
+		} else {
+			opts = c.Format.Options()
+		}
+	}
+	template = imports.GroupTemplateFile(template)
 	w := new(strings.Builder)
-	err = template.Write(w)
+	err = template.WriteWithOptions(w, opts)
 	if err != nil {
 		p.Log.Error("handleFormatting: faled to write template", zap.Error(err))
 		return
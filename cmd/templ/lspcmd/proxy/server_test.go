@@ -0,0 +1,79 @@
+package proxy
+
+import (
+	"context"
+	"testing"
+
+	lsp "github.com/a-h/protocol"
+	"go.uber.org/zap"
+)
+
+// testClient records the diagnostics published by the server.
+type testClient struct {
+	lsp.Client
+	diagnostics []lsp.Diagnostic
+}
+
+func (c *testClient) PublishDiagnostics(ctx context.Context, params *lsp.PublishDiagnosticsParams) error {
+	c.diagnostics = params.Diagnostics
+	return nil
+}
+
+func TestFormatting(t *testing.T) {
+	tests := []struct {
+		name                string
+		input               string
+		expectEdits         bool
+		expectedDiagnostics bool
+	}{
+		{
+			name: "valid templates are formatted",
+			input: `package main
+
+templ Hello() {
+<div>one</div>
+}
+`,
+			expectEdits: true,
+		},
+		{
+			name: "templates with recovered errors are not formatted",
+			input: `package main
+
+templ Hello() {
+<div>one</div>
+	<span id=>two</span>
+}
+`,
+			expectEdits:         false,
+			expectedDiagnostics: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			log := zap.NewNop()
+			s, init := NewServer(log, nil, NewSourceMapCache())
+			client := &testClient{}
+			init(client)
+			uri := lsp.DocumentURI("file:///templ-test/template.templ")
+			s.TemplSource.Set(string(uri), NewDocument(log, tt.input))
+
+			edits, err := s.Formatting(context.Background(), &lsp.DocumentFormattingParams{
+				TextDocument: lsp.TextDocumentIdentifier{URI: uri},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expectEdits && len(edits) == 0 {
+				t.Error("expected the document to be formatted")
+			}
+			if !tt.expectEdits && len(edits) != 0 {
+				t.Errorf("expected no edits, got %v", edits)
+			}
+			if tt.expectedDiagnostics && len(client.diagnostics) == 0 {
+				t.Error("expected the parse errors to be reported")
+			}
+		})
+	}
+}
@@ -7,10 +7,13 @@ import (
 	"runtime/debug"
 
 	"github.com/a-h/templ"
+	"github.com/a-h/templ/cmd/templ/astcmd"
 	"github.com/a-h/templ/cmd/templ/fmtcmd"
 	"github.com/a-h/templ/cmd/templ/generatecmd"
+	"github.com/a-h/templ/cmd/templ/lintcmd"
 	"github.com/a-h/templ/cmd/templ/lspcmd"
 	"github.com/a-h/templ/cmd/templ/migratecmd"
+	"github.com/a-h/templ/cmd/templ/output"
 )
 
 // Source builds use this value. When installed using `go install github.com/a-h/templ/cmd/templ@latest` the `version` variable is empty, but
@@ -45,9 +48,15 @@ func main() {
 	case "fmt":
 		fmtCmd(os.Args[2:])
 		return
+	case "lint":
+		lintCmd(os.Args[2:])
+		return
 	case "lsp":
 		lspCmd(os.Args[2:])
 		return
+	case "ast":
+		astCmd(os.Args[2:])
+		return
 	case "version":
 		fmt.Println(getVersion())
 		return
@@ -63,8 +72,10 @@ func usage() {
 To see help text, you can run:
   templ generate --help
   templ fmt --help
+  templ lint --help
   templ lsp --help
   templ migrate --help
+  templ ast --help
   templ version
 examples:
   templ generate`)
@@ -77,22 +88,56 @@ func generateCmd(args []string) {
 	path := cmd.String("path", ".", "Generates code for all files in path.")
 	sourceMapVisualisations := cmd.Bool("sourceMapVisualisations", false, "Set to true to generate HTML files to visualise the templ code and its corresponding Go code.")
 	workerCount := cmd.Int("w", 4, "Number of workers to run in parallel.")
+	cacheFile := cmd.String("cache", "", "Optionally stores hashes of templ files in the given file, so that unchanged files are skipped, e.g. -cache .templ-cache.json")
+	keepOrphanedFiles := cmd.Bool("keepOrphanedFiles", false, "Set to true to keep generated *_templ.go files that don't have a matching *.templ file.")
+	watchFlag := cmd.Bool("watch", false, "Set to true to watch the path for changes and regenerate code.")
+	pollInterval := cmd.Duration("pollInterval", 0, "Interval used to check for changes in watch mode if file system notifications are not available.")
+	command := cmd.String("cmd", "", "Optionally runs a command after code is successfully generated in watch mode, e.g. -cmd \"go run .\"")
+	proxy := cmd.String("proxy", "", "Optionally starts a proxy to the given URL that reloads the browser when code is generated, e.g. -proxy http://localhost:8080. Enables watch mode.")
+	proxyPort := cmd.Int("proxyport", 7331, "The port the proxy listens on.")
+	verifyFlag := cmd.Bool("verify", false, "Set to true to check that generated code is up to date, without writing files. Exits with an error if any files are out of date.")
+	diffFlag := cmd.Bool("d", false, "Set to true to print a diff of out of date files in verify mode.")
+	jsonFlag := cmd.Bool("json", false, "Set to true to write progress and errors as JSON events, one per line. Equivalent to -log-format=json.")
+	logFormatFlag := cmd.String("log-format", "text", "The format of progress and errors, text or json.")
 	helpFlag := cmd.Bool("help", false, "Print help and exit.")
 	err := cmd.Parse(args)
 	if err != nil || *helpFlag {
 		cmd.PrintDefaults()
 		return
 	}
+	logFormat := parseLogFormat(*jsonFlag, *logFormatFlag)
 	err = generatecmd.Run(generatecmd.Arguments{
 		FileName:                        *fileName,
 		Path:                            *path,
 		WorkerCount:                     *workerCount,
 		GenerateSourceMapVisualisations: *sourceMapVisualisations,
+		CacheFile:                       *cacheFile,
+		KeepOrphanedFiles:               *keepOrphanedFiles,
+		Watch:                           *watchFlag,
+		PollInterval:                    *pollInterval,
+		Command:                         *command,
+		Proxy:                           *proxy,
+		ProxyPort:                       *proxyPort,
+		Verify:                          *verifyFlag,
+		Diff:                            *diffFlag,
+		LogFormat:                       logFormat,
 	})
+	if err != nil {
+		output.New(os.Stdout, logFormat).Error(err)
+		os.Exit(1)
+	}
+}
+
+func parseLogFormat(jsonFlag bool, logFormat string) output.Format {
+	if jsonFlag {
+		return output.FormatJSON
+	}
+	format, err := output.ParseFormat(logFormat)
 	if err != nil {
 		fmt.Println(err.Error())
 		os.Exit(1)
 	}
+	return format
 }
 
 func migrateCmd(args []string) {
@@ -117,15 +162,71 @@ func migrateCmd(args []string) {
 
 func fmtCmd(args []string) {
 	cmd := flag.NewFlagSet("fmt", flag.ExitOnError)
+	checkFlag := cmd.Bool("check", false, "Set to true to list files that aren't formatted, and exit with an error if there are any, without changing them.")
+	diffFlag := cmd.Bool("d", false, "Set to true to print a diff of the formatting changes, without changing files.")
+	jsonFlag := cmd.Bool("json", false, "Set to true to write progress and errors as JSON events, one per line. Equivalent to -log-format=json.")
+	logFormatFlag := cmd.String("log-format", "text", "The format of progress and errors, text or json.")
 	helpFlag := cmd.Bool("help", false, "Print help and exit.")
 	err := cmd.Parse(args)
 	if err != nil || *helpFlag {
 		cmd.PrintDefaults()
 		return
 	}
-	err = fmtcmd.Run(args)
+	logFormat := parseLogFormat(*jsonFlag, *logFormatFlag)
+	err = fmtcmd.Run(fmtcmd.Arguments{
+		Paths:     cmd.Args(),
+		Check:     *checkFlag,
+		Diff:      *diffFlag,
+		LogFormat: logFormat,
+	})
 	if err != nil {
-		fmt.Println(err.Error())
+		output.New(os.Stdout, logFormat).Error(err)
+		os.Exit(1)
+	}
+}
+
+func lintCmd(args []string) {
+	cmd := flag.NewFlagSet("lint", flag.ExitOnError)
+	jsonFlag := cmd.Bool("json", false, "Set to true to write diagnostics and errors as JSON events, one per line. Equivalent to -log-format=json.")
+	logFormatFlag := cmd.String("log-format", "text", "The format of diagnostics and errors, text or json.")
+	helpFlag := cmd.Bool("help", false, "Print help and exit.")
+	err := cmd.Parse(args)
+	if err != nil || *helpFlag {
+		fmt.Println("usage: templ lint [flags] [paths...]\nChecks the HTML in templ files. Rule severities are set in the lint.rules section of .templ.json.\nrules:")
+		for _, r := range lintcmd.DefaultRules {
+			fmt.Printf("  %s (%s)\n    \t%s\n", r.Name, r.Severity, r.Doc)
+		}
+		fmt.Println("flags:")
+		cmd.PrintDefaults()
+		return
+	}
+	logFormat := parseLogFormat(*jsonFlag, *logFormatFlag)
+	err = lintcmd.Run(lintcmd.Arguments{
+		Paths:     cmd.Args(),
+		LogFormat: logFormat,
+	})
+	if err != nil {
+		output.New(os.Stdout, logFormat).Error(err)
+		os.Exit(1)
+	}
+}
+
+func astCmd(args []string) {
+	cmd := flag.NewFlagSet("ast", flag.ExitOnError)
+	sourceMapFlag := cmd.Bool("sourceMap", false, "Set to true to include the source map of the generated Go code.")
+	helpFlag := cmd.Bool("help", false, "Print help and exit.")
+	err := cmd.Parse(args)
+	if err != nil || *helpFlag {
+		fmt.Println("usage: templ ast [flags] [file.templ]\nPrints the parse tree of a templ file, or stdin, as JSON.")
+		cmd.PrintDefaults()
+		return
+	}
+	err = astcmd.Run(astcmd.Arguments{
+		FileName:  cmd.Arg(0),
+		SourceMap: *sourceMapFlag,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,307 @@
+// Package output writes the progress of templ commands, either as text for people to read,
+// or as JSON events, one per line, for CI systems and editor task runners.
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/a-h/parse"
+	"github.com/a-h/templ/parser/v2"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// Format of the output.
+type Format string
+
+const (
+	// FormatText writes human readable lines.
+	FormatText Format = "text"
+	// FormatJSON writes one JSON event per line.
+	FormatJSON Format = "json"
+)
+
+// ParseFormat parses the value of a -log-format flag.
+func ParseFormat(s string) (Format, error) {
+	switch Format(s) {
+	case "", FormatText:
+		return FormatText, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	}
+	return FormatText, fmt.Errorf("unknown log format %q, expected %q or %q", s, FormatText, FormatJSON)
+}
+
+// Event types.
+const (
+	TypeFile       = "file"
+	TypeDiagnostic = "diagnostic"
+	TypeSummary    = "summary"
+	TypeMessage    = "message"
+	TypeError      = "error"
+)
+
+// File statuses.
+const (
+	StatusSuccess = "success"
+	StatusError   = "error"
+	StatusDeleted = "deleted"
+	// StatusUnformatted is used by templ fmt for files that need formatting.
+	StatusUnformatted = "unformatted"
+	// StatusOutdated is used by templ generate -verify for generated files that are out of date.
+	StatusOutdated = "outdated"
+	// StatusOrphaned is used by templ generate -verify for generated files that don't have a
+	// matching templ file.
+	StatusOrphaned = "orphaned"
+)
+
+// Event is written as a single line of JSON.
+type Event struct {
+	Type string `json:"type"`
+	// File the event relates to.
+	File string `json:"file,omitempty"`
+	// Status of the file, e.g. success.
+	Status string `json:"status,omitempty"`
+	// Line and Col are the 1-based position of a diagnostic, or zero if it's not known.
+	Line int `json:"line,omitempty"`
+	Col  int `json:"col,omitempty"`
+	// Severity of a diagnostic, e.g. warning. Diagnostics without a severity are errors.
+	Severity string `json:"severity,omitempty"`
+	// Message describing the event.
+	Message string `json:"message,omitempty"`
+	// Diff is a unified diff of the changes needed to a file.
+	Diff string `json:"diff,omitempty"`
+	// Files and Errors are the counts of files processed, and files that had errors.
+	Files  *int `json:"files,omitempty"`
+	Errors *int `json:"errors,omitempty"`
+	// DurationMs is the time taken, in milliseconds.
+	DurationMs *float64 `json:"durationMs,omitempty"`
+}
+
+// Writer writes output in the configured format. It's safe for concurrent use.
+type Writer struct {
+	m      sync.Mutex
+	w      io.Writer
+	format Format
+}
+
+// New creates a Writer.
+func New(w io.Writer, format Format) *Writer {
+	return &Writer{
+		w:      w,
+		format: format,
+	}
+}
+
+// Format of the output.
+func (w *Writer) Format() Format {
+	return w.format
+}
+
+// File writes the result of processing a file. In JSON mode, errors are written as
+// diagnostics, followed by the file's status.
+func (w *Writer) File(fileName string, d time.Duration, err error) {
+	if w.format != FormatJSON {
+		if err != nil {
+			w.printf("%s\n", err.Error())
+			return
+		}
+		w.printf("%s complete in %v\n", fileName, d)
+		return
+	}
+	status := StatusSuccess
+	if err != nil {
+		status = StatusError
+		for _, diagnostic := range Diagnostics(fileName, err) {
+			w.write(diagnostic)
+		}
+	}
+	w.write(Event{
+		Type:       TypeFile,
+		File:       fileName,
+		Status:     status,
+		DurationMs: milliseconds(d),
+	})
+}
+
+// Deleted writes that a file was deleted.
+func (w *Writer) Deleted(fileName string) {
+	if w.format != FormatJSON {
+		w.printf("Deleted orphaned file %q\n", fileName)
+		return
+	}
+	w.write(Event{
+		Type:   TypeFile,
+		File:   fileName,
+		Status: StatusDeleted,
+	})
+}
+
+// Changed writes that a file needs changing, e.g. because it isn't formatted. In text mode,
+// the diff is written if there is one, otherwise the file name.
+func (w *Writer) Changed(fileName, status, diff string) {
+	if w.format != FormatJSON {
+		if diff != "" {
+			w.printf("%s", diff)
+			return
+		}
+		w.printf("%s\n", fileName)
+		return
+	}
+	w.write(Event{
+		Type:   TypeFile,
+		File:   fileName,
+		Status: status,
+		Diff:   diff,
+	})
+}
+
+// Summary writes the totals after processing files. The message is used in text mode.
+func (w *Writer) Summary(message string, files, errorCount int, d time.Duration) {
+	if w.format != FormatJSON {
+		w.printf("%s\n", message)
+		return
+	}
+	w.write(Event{
+		Type:       TypeSummary,
+		Message:    message,
+		Files:      &files,
+		Errors:     &errorCount,
+		DurationMs: milliseconds(d),
+	})
+}
+
+// Message writes general information.
+func (w *Writer) Message(format string, a ...any) {
+	msg := fmt.Sprintf(format, a...)
+	if w.format != FormatJSON {
+		w.printf("%s\n", msg)
+		return
+	}
+	w.write(Event{
+		Type:    TypeMessage,
+		Message: msg,
+	})
+}
+
+// Error writes an error, unless it's marked as Reported, because it's already been written.
+func (w *Writer) Error(err error) {
+	var re reportedError
+	if errors.As(err, &re) {
+		return
+	}
+	if w.format != FormatJSON {
+		w.printf("%s\n", err.Error())
+		return
+	}
+	w.write(Event{
+		Type:    TypeError,
+		Message: err.Error(),
+	})
+}
+
+// Diagnostic writes a problem found at a 0-based line and column of a file, e.g. by a lint rule.
+func (w *Writer) Diagnostic(fileName string, line, col int, severity, message string) {
+	if w.format != FormatJSON {
+		w.printf("%s:%d:%d: %s: %s\n", fileName, line+1, col+1, severity, message)
+		return
+	}
+	w.write(Event{
+		Type:     TypeDiagnostic,
+		File:     fileName,
+		Line:     line + 1,
+		Col:      col + 1,
+		Severity: severity,
+		Message:  message,
+	})
+}
+
+// Diagnostics returns a diagnostic event for each error, including the position of parse
+// errors. Each error that the parser recovered from is written as its own diagnostic.
+func Diagnostics(fileName string, err error) (events []Event) {
+	var errs []error
+	var parseErrs parser.Errors
+	if errors.As(err, &parseErrs) {
+		errs = parseErrs.Unwrap()
+	} else if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	} else {
+		errs = []error{err}
+	}
+	for _, err := range errs {
+		e := Event{
+			Type:    TypeDiagnostic,
+			File:    fileName,
+			Message: err.Error(),
+		}
+		var te parser.Error
+		var pe parse.ParseError
+		switch {
+		case errors.As(err, &te):
+			e.Line = int(te.Pos.Line) + 1
+			e.Col = int(te.Pos.Col) + 1
+			e.Message = te.Msg
+		case errors.As(err, &pe):
+			e.Line = pe.Pos.Line + 1
+			e.Col = pe.Pos.Col + 1
+			e.Message = pe.Msg
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+// UnifiedDiff returns a unified diff of the changes from the original to the updated file.
+func UnifiedDiff(fileName string, original, updated []byte) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(original)),
+		B:        difflib.SplitLines(string(updated)),
+		FromFile: fileName + ".orig",
+		ToFile:   fileName,
+		Context:  3,
+	})
+}
+
+// Reported marks an error as already having been written by the Writer.
+func Reported(err error) error {
+	if err == nil {
+		return nil
+	}
+	return reportedError{err: err}
+}
+
+type reportedError struct {
+	err error
+}
+
+func (e reportedError) Error() string {
+	return e.err.Error()
+}
+
+func (e reportedError) Unwrap() error {
+	return e.err
+}
+
+func (w *Writer) printf(format string, a ...any) {
+	w.m.Lock()
+	defer w.m.Unlock()
+	fmt.Fprintf(w.w, format, a...)
+}
+
+func (w *Writer) write(e Event) {
+	w.m.Lock()
+	defer w.m.Unlock()
+	// Events only contain strings and numbers, so they can't fail to encode.
+	enc := json.NewEncoder(w.w)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(e)
+}
+
+func milliseconds(d time.Duration) *float64 {
+	ms := float64(d) / float64(time.Millisecond)
+	return &ms
+}
@@ -0,0 +1,116 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/a-h/parse"
+	"github.com/a-h/templ/parser/v2"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWriter(t *testing.T) {
+	parseErr := fmt.Errorf("a.templ parsing error: %w", parse.Error("<div>: malformed open element", parse.Position{Index: 20, Line: 3, Col: 1}))
+	tests := []struct {
+		name     string
+		format   Format
+		write    func(w *Writer)
+		expected string
+	}{
+		{
+			name:   "text: files and summaries are written as lines",
+			format: FormatText,
+			write: func(w *Writer) {
+				w.File("a.templ", time.Millisecond, nil)
+				w.File("b.templ", time.Millisecond, parseErr)
+				w.Deleted("c_templ.go")
+				w.Summary("Generated code for 2 templates with 1 errors in 2ms", 2, 1, 2*time.Millisecond)
+			},
+			expected: `a.templ complete in 1ms
+a.templ parsing error: <div>: malformed open element: line 3, col 1
+Deleted orphaned file "c_templ.go"
+Generated code for 2 templates with 1 errors in 2ms
+`,
+		},
+		{
+			name:   "json: parse errors are written as diagnostics with 1-based positions",
+			format: FormatJSON,
+			write: func(w *Writer) {
+				w.File("a.templ", time.Millisecond, nil)
+				w.File("b.templ", time.Millisecond, parseErr)
+				w.Deleted("c_templ.go")
+				w.Summary("Generated code for 2 templates with 1 errors in 2ms", 2, 1, 2*time.Millisecond)
+			},
+			expected: `{"type":"file","file":"a.templ","status":"success","durationMs":1}
+{"type":"diagnostic","file":"b.templ","line":4,"col":2,"message":"<div>: malformed open element"}
+{"type":"file","file":"b.templ","status":"error","durationMs":1}
+{"type":"file","file":"c_templ.go","status":"deleted"}
+{"type":"summary","message":"Generated code for 2 templates with 1 errors in 2ms","files":2,"errors":1,"durationMs":2}
+`,
+		},
+		{
+			name:   "json: errors without a position are written as messages",
+			format: FormatJSON,
+			write: func(w *Writer) {
+				w.File("a.templ", time.Millisecond, errors.New("a.templ read file error"))
+				w.Error(errors.New("failed to load cache"))
+			},
+			expected: `{"type":"diagnostic","file":"a.templ","message":"a.templ read file error"}
+{"type":"file","file":"a.templ","status":"error","durationMs":1}
+{"type":"error","message":"failed to load cache"}
+`,
+		},
+		{
+			name:   "json: each error that the parser recovered from is written as a diagnostic",
+			format: FormatJSON,
+			write: func(w *Writer) {
+				w.File("a.templ", time.Millisecond, fmt.Errorf("a.templ parsing error: %w", parser.Errors{
+					{Msg: "<a>: mismatched end tag, expected '</a>', got '</b>'", Pos: parser.NewPosition(38, 4, 5)},
+					{Msg: "<div>: expected end tag not present or invalid tag contents", Pos: parser.NewPosition(135, 17, 0)},
+				}))
+			},
+			expected: `{"type":"diagnostic","file":"a.templ","line":5,"col":6,"message":"<a>: mismatched end tag, expected '</a>', got '</b>'"}
+{"type":"diagnostic","file":"a.templ","line":18,"col":1,"message":"<div>: expected end tag not present or invalid tag contents"}
+{"type":"file","file":"a.templ","status":"error","durationMs":1}
+`,
+		},
+		{
+			name:   "text: diagnostics are written with 1-based positions",
+			format: FormatText,
+			write: func(w *Writer) {
+				w.Diagnostic("a.templ", 3, 1, "warning", "<center> is deprecated, use CSS instead (deprecated-element)")
+			},
+			expected: "a.templ:4:2: warning: <center> is deprecated, use CSS instead (deprecated-element)\n",
+		},
+		{
+			name:   "json: diagnostics include their severity",
+			format: FormatJSON,
+			write: func(w *Writer) {
+				w.Diagnostic("a.templ", 3, 1, "warning", "<center> is deprecated, use CSS instead (deprecated-element)")
+			},
+			expected: `{"type":"diagnostic","file":"a.templ","line":4,"col":2,"severity":"warning","message":"<center> is deprecated, use CSS instead (deprecated-element)"}
+`,
+		},
+		{
+			name:   "reported errors are not written again",
+			format: FormatText,
+			write: func(w *Writer) {
+				w.Error(Reported(errors.New("a.templ: parsing error")))
+			},
+			expected: "",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			tt.write(New(buf, tt.format))
+			if diff := cmp.Diff(tt.expected, buf.String()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -18,8 +18,11 @@ func Process(dir string, f func(fileName string) error, workerCount int, results
 	defer close(results)
 	templates := make(chan string)
 	go func() {
+		// The error has to be sent before the templates channel is closed, since the
+		// results channel is closed once the workers have finished.
+		defer close(templates)
 		if err := getTemplates(dir, templates); err != nil {
-			results <- Result{Error: err}
+			results <- Result{FileName: dir, Error: err}
 		}
 	}()
 	var wg sync.WaitGroup
@@ -41,11 +44,25 @@ func Process(dir string, f func(fileName string) error, workerCount int, results
 }
 
 func getTemplates(srcPath string, output chan<- string) (err error) {
-	defer close(output)
-	return filepath.Walk(srcPath, func(currentPath string, info fs.FileInfo, err error) error {
-		if !info.IsDir() && strings.HasSuffix(currentPath, ".templ") {
+	return filepath.WalkDir(srcPath, func(currentPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if currentPath != srcPath && SkipDir(d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(currentPath, ".templ") {
 			output <- currentPath
 		}
 		return nil
 	})
 }
+
+// SkipDir returns true for directories that don't contain templates that are being worked
+// on, and can contain a lot of files that would use up OS file watches or slow down walks.
+func SkipDir(name string) bool {
+	return name == "node_modules" || name == "vendor" || (strings.HasPrefix(name, ".") && name != "." && name != "..")
+}
@@ -0,0 +1,64 @@
+package processor
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestProcess(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"a.templ",
+		"sub/b.templ",
+		"sub/b_templ.go",
+		"vendor/example.com/c.templ",
+		"node_modules/example/d.templ",
+		".git/e.templ",
+	} {
+		fileName := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(fileName, nil, 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+	results := make(chan Result)
+	go Process(dir, func(fileName string) error { return nil }, 2, results)
+	var actual []string
+	for r := range results {
+		if r.Error != nil {
+			t.Errorf("%s: unexpected error: %v", r.FileName, r.Error)
+		}
+		actual = append(actual, r.FileName)
+	}
+	sort.Strings(actual)
+	expected := []string{filepath.Join(dir, "a.templ"), filepath.Join(dir, "sub", "b.templ")}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestSkipDir(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected bool
+	}{
+		{name: "components", expected: false},
+		{name: ".", expected: false},
+		{name: "..", expected: false},
+		{name: ".git", expected: true},
+		{name: ".vscode", expected: true},
+		{name: "node_modules", expected: true},
+		{name: "vendor", expected: true},
+	}
+	for _, tt := range tests {
+		if actual := SkipDir(tt.name); actual != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, actual)
+		}
+	}
+}
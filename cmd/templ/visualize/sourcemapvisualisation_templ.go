@@ -71,7 +71,11 @@ func combine(templFileName string, left, right templ.Component) templ.Component
 			return err
 		}
 		// StringExpression
-		var var_2 string = templFileName
+		var var_2 string
+		var_2, err = templ.ToString(templFileName)
+		if err != nil {
+			return err
+		}
 		_, err = templBuffer.WriteString(templ.EscapeString(var_2))
 		if err != nil {
 			return err
@@ -128,7 +132,11 @@ if err != nil {
 			return err
 		}
 		// StringExpression
-		var var_5 string = templFileName
+		var var_5 string
+		var_5, err = templ.ToString(templFileName)
+		if err != nil {
+			return err
+		}
 		_, err = templBuffer.WriteString(templ.EscapeString(var_5))
 		if err != nil {
 			return err
@@ -385,7 +393,11 @@ func mappedCharacter(s string, sourceID, targetID string) templ.Component {
 			return err
 		}
 		// StringExpression
-		var var_13 string = s
+		var var_13 string
+		var_13, err = templ.ToString(s)
+		if err != nil {
+			return err
+		}
 		_, err = templBuffer.WriteString(templ.EscapeString(var_13))
 		if err != nil {
 			return err
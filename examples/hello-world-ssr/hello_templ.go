@@ -34,7 +34,11 @@ func hello(name string) templ.Component {
 			return err
 		}
 		// StringExpression
-		var var_3 string = name
+		var var_3 string
+		var_3, err = templ.ToString(name)
+		if err != nil {
+			return err
+		}
 		_, err = templBuffer.WriteString(templ.EscapeString(var_3))
 		if err != nil {
 			return err
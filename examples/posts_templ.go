@@ -46,7 +46,11 @@ func headerTemplate(name string) templ.Component {
 			return err
 		}
 		// StringExpression
-		var var_2 string = name
+		var var_2 string
+		var_2, err = templ.ToString(name)
+		if err != nil {
+			return err
+		}
 		_, err = templBuffer.WriteString(templ.EscapeString(var_2))
 		if err != nil {
 			return err
@@ -105,7 +109,11 @@ func footerTemplate() templ.Component {
 			return err
 		}
 		// StringExpression
-		var var_5 string = fmt.Sprintf("%d", time.Now().Year())
+		var var_5 string
+		var_5, err = templ.ToString(fmt.Sprintf("%d", time.Now().Year()))
+		if err != nil {
+			return err
+		}
 		_, err = templBuffer.WriteString(templ.EscapeString(var_5))
 		if err != nil {
 			return err
@@ -267,7 +275,11 @@ func layout(name string) templ.Component {
 			return err
 		}
 		// StringExpression
-		var var_10 string = name
+		var var_10 string
+		var_10, err = templ.ToString(name)
+		if err != nil {
+			return err
+		}
 		_, err = templBuffer.WriteString(templ.EscapeString(var_10))
 		if err != nil {
 			return err
@@ -387,7 +399,11 @@ func postsTemplate(posts []Post) templ.Component {
 				return err
 			}
 			// StringExpression
-			var var_12 string = p.Name
+			var var_12 string
+			var_12, err = templ.ToString(p.Name)
+			if err != nil {
+				return err
+			}
 			_, err = templBuffer.WriteString(templ.EscapeString(var_12))
 			if err != nil {
 				return err
@@ -411,7 +427,11 @@ func postsTemplate(posts []Post) templ.Component {
 				return err
 			}
 			// StringExpression
-			var var_13 string = p.Author
+			var var_13 string
+			var_13, err = templ.ToString(p.Author)
+			if err != nil {
+				return err
+			}
 			_, err = templBuffer.WriteString(templ.EscapeString(var_13))
 			if err != nil {
 				return err
@@ -35,7 +35,11 @@ func list(items []string) templ.Component {
 				return err
 			}
 			// StringExpression
-			var var_2 string = item
+			var var_2 string
+			var_2, err = templ.ToString(item)
+			if err != nil {
+				return err
+			}
 			_, err = templBuffer.WriteString(templ.EscapeString(var_2))
 			if err != nil {
 				return err
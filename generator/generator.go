@@ -1,28 +1,86 @@
 package generator
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"go/token"
+	"go/types"
 	"html"
 	"io"
+	"path/filepath"
 	"reflect"
 	"runtime/debug"
 	"strings"
 
 	"github.com/a-h/templ"
+	"github.com/a-h/templ/generator/imports"
 	"github.com/a-h/templ/parser/v2"
 )
 
-func Generate(template parser.TemplateFile, w io.Writer) (sm *parser.SourceMap, err error) {
-	g := generator{
-		tf:        template,
-		w:         NewRangeWriter(w),
-		sourceMap: parser.NewSourceMap(),
+// GenerateOpt is an option for the Generate function.
+type GenerateOpt func(g *generator)
+
+// WithFileName sets the name of the templ file being generated. It's used to find the
+// Go module that the file belongs to, so that missing imports can be resolved.
+func WithFileName(name string) GenerateOpt {
+	return func(g *generator) {
+		g.fileName = name
+	}
+}
+
+// WithResolver sets the resolver used to find missing imports, so that it can be
+// shared between files in the same package. The resolver must have been created for
+// the package that contains the file set by WithFileName.
+func WithResolver(r *imports.Resolver) GenerateOpt {
+	return func(g *generator) {
+		g.resolver = r
 	}
-	err = g.generate()
-	sm = g.sourceMap
-	return
+}
+
+func Generate(template parser.TemplateFile, w io.Writer, opts ...GenerateOpt) (sm *parser.SourceMap, err error) {
+	g := &generator{
+		tf: template,
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	if g.resolver == nil {
+		var dir string
+		if g.fileName != "" {
+			dir = filepath.Dir(g.fileName)
+		}
+		g.resolver = imports.NewResolver(dir)
+	}
+	// The first pass finds out which imports are missing or unused.
+	first := new(bytes.Buffer)
+	if err = g.run(first); err != nil {
+		return g.sourceMap, err
+	}
+	result, err := g.resolver.Analyze(g.fileName, first.Bytes())
+	if err != nil {
+		return g.sourceMap, err
+	}
+	code := first
+	if result.Changed() {
+		// The second pass writes the code again with the imports fixed, so that
+		// the source map is correct.
+		g.fixImports(result)
+		code = new(bytes.Buffer)
+		if err = g.run(code); err != nil {
+			return g.sourceMap, err
+		}
+	}
+	if g.findStringers(code.Bytes()) {
+		// The final pass converts the values of fmt.Stringer expressions with templ.StringerToString.
+		code.Reset()
+		if err = g.run(code); err != nil {
+			return g.sourceMap, err
+		}
+	}
+	_, err = code.WriteTo(w)
+	return g.sourceMap, err
 }
 
 type generator struct {
@@ -31,6 +89,135 @@ type generator struct {
 	sourceMap   *parser.SourceMap
 	variableID  int
 	childrenVar string
+
+	fileName string
+	resolver *imports.Resolver
+	// importsRange is the location of the imports written by the generator.
+	importsRange parser.Range
+	// goExpressionRanges are the locations of the Go expressions written by the generator.
+	goExpressionRanges map[int64]parser.Range
+	// missingImports are added to the imports written by the generator.
+	missingImports []imports.Import
+	// unusedImports are not written by the generator.
+	unusedImports map[string]struct{}
+	// goExpressionBlanks are ranges within Go expressions that contain unused imports.
+	goExpressionBlanks map[int64][][2]int
+	// stringExpressionRanges are the locations of the string expressions written by the generator.
+	stringExpressionRanges map[int64]parser.Range
+	// stringers are the string expressions whose values are converted with templ.StringerToString.
+	stringers map[int64]struct{}
+}
+
+func (g *generator) run(w io.Writer) (err error) {
+	g.w = NewRangeWriter(w)
+	g.sourceMap = parser.NewSourceMap()
+	g.variableID = 0
+	g.childrenVar = ""
+	g.goExpressionRanges = nil
+	g.stringExpressionRanges = nil
+	return g.generate()
+}
+
+// fixImports uses the results of analysing the first pass to decide which imports
+// should be written during the second pass.
+func (g *generator) fixImports(result imports.Result) {
+	g.missingImports = result.Missing
+	g.unusedImports = make(map[string]struct{})
+	g.goExpressionBlanks = make(map[int64][][2]int)
+	userImports := make(map[string]struct{})
+	for _, imp := range result.Imports {
+		if g.isGeneratorImport(imp) {
+			continue
+		}
+		userImports[imp.Path] = struct{}{}
+	}
+	for _, imp := range result.Imports {
+		if g.isGeneratorImport(imp) {
+			// Don't import packages that aren't used, or that the template file already imports.
+			if _, isUserImport := userImports[imp.Path]; !imp.Used || isUserImport {
+				g.unusedImports[imp.Path] = struct{}{}
+			}
+			continue
+		}
+		if imp.Used {
+			continue
+		}
+		// Blank out unused imports in Go expressions, since removing them would move the
+		// code that follows them.
+		for key, r := range g.goExpressionRanges {
+			if int64(imp.Offset) >= r.From.Index && int64(imp.End) <= r.To.Index {
+				blank := [2]int{imp.Offset - int(r.From.Index), imp.End - int(r.From.Index)}
+				g.goExpressionBlanks[key] = append(g.goExpressionBlanks[key], blank)
+			}
+		}
+	}
+}
+
+// findStringers uses the types of the string expressions written by the previous pass to decide
+// which should be converted with templ.StringerToString. templ.ToString only accepts strings,
+// bools and numbers, so that other types fail to compile, but types such as time.Time and
+// *url.URL implement fmt.Stringer. It returns true if the code needs to be written again.
+func (g *generator) findStringers(src []byte) bool {
+	keys := make([]int64, 0, len(g.stringExpressionRanges))
+	ranges := make([][2]int, 0, len(g.stringExpressionRanges))
+	for key, r := range g.stringExpressionRanges {
+		keys = append(keys, key)
+		ranges = append(ranges, [2]int{int(r.From.Index), int(r.To.Index)})
+	}
+	g.stringers = make(map[int64]struct{})
+	for i, t := range g.resolver.TypesOf(g.fileName, src, ranges) {
+		if isStringer(t) {
+			g.stringers[keys[i]] = struct{}{}
+		}
+	}
+	return len(g.stringers) > 0
+}
+
+var stringerType = types.NewInterfaceType([]*types.Func{
+	types.NewFunc(token.NoPos, nil, "String", types.NewSignatureType(nil, nil, nil, nil,
+		types.NewTuple(types.NewVar(token.NoPos, nil, "", types.Typ[types.String])), false)),
+}, nil).Complete()
+
+// isStringer returns true if values of the type implement fmt.Stringer, and can't be
+// converted by templ.ToString.
+func isStringer(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+	if _, isBasic := t.Underlying().(*types.Basic); isBasic {
+		return false
+	}
+	return types.Implements(t, stringerType)
+}
+
+// writeToString writes the start of the call that converts the value of the string
+// expression to a string, and records the location of the expression once it's written.
+func (g *generator) writeToString(indentLevel int, vn string, e parser.Expression) (err error) {
+	f := "templ.ToString("
+	if _, isStringer := g.stringers[e.Range.From.Index]; isStringer {
+		f = "templ.StringerToString("
+	}
+	// vn, err = templ.ToString(
+	if _, err = g.w.WriteIndent(indentLevel, vn+", err = "+f); err != nil {
+		return err
+	}
+	// p.Name()
+	var r parser.Range
+	if r, err = g.w.Write(e.Value); err != nil {
+		return err
+	}
+	g.sourceMap.Add(e, r)
+	if g.stringExpressionRanges == nil {
+		g.stringExpressionRanges = make(map[int64]parser.Range)
+	}
+	g.stringExpressionRanges[e.Range.From.Index] = r
+	// )
+	_, err = g.w.Write(")\n")
+	return err
+}
+
+func (g *generator) isGeneratorImport(imp imports.Import) bool {
+	return int64(imp.Offset) >= g.importsRange.From.Index && int64(imp.End) <= g.importsRange.To.Index
 }
 
 func (g *generator) generate() (err error) {
@@ -69,6 +256,11 @@ func getVersion() string {
 	return goInstallVersion()
 }
 
+// Version returns the templ version written to the header of generated files.
+func Version() string {
+	return getVersion()
+}
+
 func (g *generator) writeCodeGeneratedComment() error {
 	_, err := g.w.Write(fmt.Sprintf("// Code generated by templ@%s DO NOT EDIT.\n\n", getVersion()))
 	return err
@@ -105,37 +297,53 @@ func (g *generator) templateNodeInfo() (hasTemplates bool, hasCSS bool) {
 
 func (g *generator) writeImports() error {
 	var err error
+	g.importsRange.From = g.w.Current
 	// Always import templ because it's the interface type of all templates.
-	if _, err = g.w.Write("import \"github.com/a-h/templ\"\n"); err != nil {
+	if err = g.writeImport("github.com/a-h/templ"); err != nil {
 		return err
 	}
 	hasTemplates, hasCSS := g.templateNodeInfo()
 	if hasTemplates {
 		// The first parameter of a template function.
-		if _, err = g.w.Write("import \"context\"\n"); err != nil {
+		if err = g.writeImport("context"); err != nil {
 			return err
 		}
 		// The second parameter of a template function.
-		if _, err = g.w.Write("import \"io\"\n"); err != nil {
+		if err = g.writeImport("io"); err != nil {
 			return err
 		}
 		// Buffer namespace.
-		if _, err = g.w.Write("import \"bytes\"\n"); err != nil {
+		if err = g.writeImport("bytes"); err != nil {
 			return err
 		}
 	}
 	if hasCSS {
 		// strings.Builder is used to create CSS.
-		if _, err = g.w.Write("import \"strings\"\n"); err != nil {
+		if err = g.writeImport("strings"); err != nil {
+			return err
+		}
+	}
+	// Packages used by expressions, but not imported by the template file.
+	for _, imp := range g.missingImports {
+		if _, err = g.w.Write("import " + imp.String() + "\n"); err != nil {
 			return err
 		}
 	}
+	g.importsRange.To = g.w.Current
 	if _, err = g.w.Write("\n"); err != nil {
 		return err
 	}
 	return nil
 }
 
+func (g *generator) writeImport(path string) (err error) {
+	if _, isUnused := g.unusedImports[path]; isUnused {
+		return nil
+	}
+	_, err = g.w.Write("import \"" + path + "\"\n")
+	return err
+}
+
 func (g *generator) writeTemplateNodes() error {
 	for i := 0; i < len(g.tf.Nodes); i++ {
 		switch n := g.tf.Nodes[i].(type) {
@@ -175,8 +383,20 @@ func (g *generator) writeCSS(n parser.CSSTemplate) error {
 		return err
 	}
 	g.sourceMap.Add(n.Name, r)
-	// () templ.CSSClass {
-	if _, err = g.w.Write("() templ.CSSClass {\n"); err != nil {
+	// (
+	if _, err = g.w.Write("("); err != nil {
+		return err
+	}
+	// Write parameters.
+	if r, err = g.w.Write(n.Parameters.Value); err != nil {
+		return err
+	}
+	g.sourceMap.Add(n.Parameters, r)
+	if n.Type != parser.CSSTemplateTypeClass {
+		return g.writeCSSAtRule(n)
+	}
+	// ) templ.CSSClass {
+	if _, err = g.w.Write(") templ.CSSClass {\n"); err != nil {
 		return err
 	}
 	{
@@ -185,30 +405,16 @@ func (g *generator) writeCSS(n parser.CSSTemplate) error {
 		if _, err = g.w.WriteIndent(indentLevel, "var templCSSBuilder strings.Builder\n"); err != nil {
 			return err
 		}
-		for i := 0; i < len(n.Properties); i++ {
-			switch p := n.Properties[i].(type) {
-			case parser.ConstantCSSProperty:
-				// Carry out sanitization at compile time for constants.
-				if _, err = g.w.WriteIndent(indentLevel, fmt.Sprintf("templCSSBuilder.WriteString(`%s`)\n", templ.SanitizeCSS(p.Name, p.Value))); err != nil {
-					return err
-				}
-			case parser.ExpressionCSSProperty:
-				// templCSSBuilder.WriteString(templ.SanitizeCSS('name', p.Expression()))
-				if _, err = g.w.WriteIndent(indentLevel, fmt.Sprintf("templCSSBuilder.WriteString(string(templ.SanitizeCSS(`%s`, ", p.Name)); err != nil {
-					return err
-				}
-				if r, err = g.w.Write(p.Value.Expression.Value); err != nil {
-					return err
-				}
-				g.sourceMap.Add(p.Value.Expression, r)
-				if _, err = g.w.Write(")))\n"); err != nil {
-					return err
-				}
-			default:
-				return fmt.Errorf("unknown CSS property type: %v", reflect.TypeOf(p))
-			}
+		if err = g.writeCSSDeclarations(indentLevel, "templCSSBuilder", n.Properties); err != nil {
+			return err
+		}
+		// Nested rules are written to their own builders.
+		var rules cssRules
+		if err = g.writeCSSRules(indentLevel, "&", n.Properties, &rules); err != nil {
+			return err
 		}
-		if _, err = g.w.WriteIndent(indentLevel, fmt.Sprintf("templCSSID := templ.CSSID(`%s`, templCSSBuilder.String())\n", n.Name.Value)); err != nil {
+		hash := strings.Join(append([]string{"templCSSBuilder.String()"}, rules.hash...), " + ")
+		if _, err = g.w.WriteIndent(indentLevel, fmt.Sprintf("templCSSID := templ.CSSID(`%s`, %s)\n", n.Name.Value, hash)); err != nil {
 			return err
 		}
 		// return templ.CSS {
@@ -222,7 +428,8 @@ func (g *generator) writeCSS(n parser.CSSTemplate) error {
 				return err
 			}
 			// Class: templ.SafeCSS(".cssID{" + templ.CSSBuilder.String() + "}"),
-			if _, err = g.w.WriteIndent(indentLevel, "Class: templ.SafeCSS(`.` + templCSSID + `{` + templCSSBuilder.String() + `}`),\n"); err != nil {
+			class := strings.Join(append([]string{"`.` + templCSSID + `{` + templCSSBuilder.String() + `}`"}, rules.class...), " + ")
+			if _, err = g.w.WriteIndent(indentLevel, fmt.Sprintf("Class: templ.SafeCSS(%s),\n", class)); err != nil {
 				return err
 			}
 			indentLevel--
@@ -239,21 +446,271 @@ func (g *generator) writeCSS(n parser.CSSTemplate) error {
 	return nil
 }
 
+// writeCSSAtRule writes the body of a css keyframes or css fontface template, which
+// returns a @keyframes or @font-face rule named after the template and the hash of its CSS.
+func (g *generator) writeCSSAtRule(n parser.CSSTemplate) (err error) {
+	indentLevel := 1
+	// ) templ.ComponentCSSAtRule {
+	if _, err = g.w.Write(") templ.ComponentCSSAtRule {\n"); err != nil {
+		return err
+	}
+	var rules cssRules
+	switch n.Type {
+	case parser.CSSTemplateTypeKeyframes:
+		rules.class = appendCSSLiteral([]string{"`@keyframes `", "templCSSID"}, "{")
+		for _, p := range n.Properties {
+			if keyframe, ok := p.(parser.CSSRule); ok {
+				if err = g.writeCSSRuleBlock(indentLevel, keyframe.Selector, keyframe.Properties, &rules); err != nil {
+					return err
+				}
+			}
+		}
+		rules.class = appendCSSLiteral(rules.class, "}")
+		if len(rules.hash) == 0 {
+			rules.hash = []string{"``"}
+		}
+	case parser.CSSTemplateTypeFontFace:
+		// var templCSSBuilder strings.Builder
+		if _, err = g.w.WriteIndent(indentLevel, "var templCSSBuilder strings.Builder\n"); err != nil {
+			return err
+		}
+		if err = g.writeCSSDeclarations(indentLevel, "templCSSBuilder", n.Properties); err != nil {
+			return err
+		}
+		rules.class = []string{"`@font-face{font-family:\"` + templCSSID + `\";` + templCSSBuilder.String() + `}`"}
+		rules.hash = []string{"templCSSBuilder.String()"}
+	default:
+		return fmt.Errorf("unknown CSS template type: %v", n.Type)
+	}
+	if _, err = g.w.WriteIndent(indentLevel, fmt.Sprintf("templCSSID := templ.CSSID(`%s`, %s)\n", n.Name.Value, strings.Join(rules.hash, " + "))); err != nil {
+		return err
+	}
+	// return templ.ComponentCSSAtRule{
+	if _, err = g.w.WriteIndent(indentLevel, "return templ.ComponentCSSAtRule{\n"); err != nil {
+		return err
+	}
+	// Name: templCSSID,
+	if _, err = g.w.WriteIndent(indentLevel+1, "Name: templCSSID,\n"); err != nil {
+		return err
+	}
+	// Rule: templ.SafeCSS("@keyframes " + templCSSID + "{" + ... + "}"),
+	if _, err = g.w.WriteIndent(indentLevel+1, fmt.Sprintf("Rule: templ.SafeCSS(%s),\n", strings.Join(rules.class, " + "))); err != nil {
+		return err
+	}
+	if _, err = g.w.WriteIndent(indentLevel, "}\n"); err != nil {
+		return err
+	}
+	// }
+	if _, err = g.w.WriteIndent(0, "}\n\n"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// writeCSSDeclarations writes the declarations in properties to the named strings.Builder,
+// skipping nested rules.
+func (g *generator) writeCSSDeclarations(indentLevel int, builder string, properties []parser.CSSProperty) (err error) {
+	var r parser.Range
+	for i := 0; i < len(properties); i++ {
+		switch p := properties[i].(type) {
+		case parser.ConstantCSSProperty:
+			// Carry out sanitization at compile time for constants.
+			if _, err = g.w.WriteIndent(indentLevel, fmt.Sprintf("%s.WriteString(`%s`)\n", builder, templ.SanitizeCSS(p.Name, p.Value))); err != nil {
+				return err
+			}
+		case parser.ExpressionCSSProperty:
+			// templCSSBuilder.WriteString(templ.SanitizeCSS('name', p.Expression()))
+			if _, err = g.w.WriteIndent(indentLevel, fmt.Sprintf("%s.WriteString(string(templ.SanitizeCSS(`%s`, ", builder, p.Name)); err != nil {
+				return err
+			}
+			if r, err = g.w.Write(p.Value.Expression.Value); err != nil {
+				return err
+			}
+			g.sourceMap.Add(p.Value.Expression, r)
+			if _, err = g.w.Write(")))\n"); err != nil {
+				return err
+			}
+		case parser.CSSRule:
+			continue
+		default:
+			return fmt.Errorf("unknown CSS property type: %v", reflect.TypeOf(p))
+		}
+	}
+	return nil
+}
+
+// cssRules are the Go expressions that make up the nested rules of a css template. The
+// class ID depends on the hash of the CSS, so the hash is calculated without the ID.
+type cssRules struct {
+	class    []string
+	hash     []string
+	builders int
+}
+
+// literal adds CSS text to the rules.
+func (r *cssRules) literal(s string) {
+	r.class = appendCSSLiteral(r.class, s)
+	r.hash = appendCSSLiteral(r.hash, s)
+}
+
+// appendCSSLiteral appends s as a raw string literal, joining it to the previous
+// expression if it's also a literal.
+func appendCSSLiteral(expressions []string, s string) []string {
+	if i := len(expressions) - 1; i >= 0 && strings.HasSuffix(expressions[i], "`") {
+		expressions[i] = strings.TrimSuffix(expressions[i], "`") + s + "`"
+		return expressions
+	}
+	return append(expressions, "`"+s+"`")
+}
+
+// selector adds a selector to the rules, replacing & with the class.
+func (r *cssRules) selector(s string) {
+	for i, part := range strings.Split(s, "&") {
+		if i > 0 {
+			r.class = append(appendCSSLiteral(r.class, "."), "templCSSID")
+		}
+		if part != "" {
+			r.literal(part)
+		}
+	}
+}
+
+// writeCSSRules writes the nested rules in properties, scoped to the parent selector.
+func (g *generator) writeCSSRules(indentLevel int, parent string, properties []parser.CSSProperty, rules *cssRules) (err error) {
+	for _, p := range properties {
+		rule, ok := p.(parser.CSSRule)
+		if !ok {
+			continue
+		}
+		if strings.HasPrefix(rule.Selector, "@") {
+			// At-rules apply their properties to the parent.
+			rules.literal(rule.Selector + "{")
+			if err = g.writeCSSRuleBlock(indentLevel, parent, rule.Properties, rules); err != nil {
+				return err
+			}
+			if err = g.writeCSSRules(indentLevel, parent, rule.Properties, rules); err != nil {
+				return err
+			}
+			rules.literal("}")
+			continue
+		}
+		selector := nestCSSSelector(parent, rule.Selector)
+		if err = g.writeCSSRuleBlock(indentLevel, selector, rule.Properties, rules); err != nil {
+			return err
+		}
+		if err = g.writeCSSRules(indentLevel, selector, rule.Properties, rules); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeCSSRuleBlock writes the declarations in properties to a new strings.Builder, and
+// adds selector{declarations} to the rules.
+func (g *generator) writeCSSRuleBlock(indentLevel int, selector string, properties []parser.CSSProperty, rules *cssRules) (err error) {
+	if !hasCSSDeclarations(properties) {
+		return nil
+	}
+	rules.builders++
+	builder := fmt.Sprintf("templCSSBuilder%d", rules.builders)
+	if _, err = g.w.WriteIndent(indentLevel, fmt.Sprintf("var %s strings.Builder\n", builder)); err != nil {
+		return err
+	}
+	if err = g.writeCSSDeclarations(indentLevel, builder, properties); err != nil {
+		return err
+	}
+	rules.selector(selector + "{")
+	rules.class = append(rules.class, builder+".String()")
+	rules.hash = append(rules.hash, builder+".String()")
+	rules.literal("}")
+	return nil
+}
+
+func hasCSSDeclarations(properties []parser.CSSProperty) bool {
+	for _, p := range properties {
+		if _, isRule := p.(parser.CSSRule); !isRule {
+			return true
+		}
+	}
+	return false
+}
+
+// nestCSSSelector returns the selector of a rule nested inside parent. & is replaced with
+// the parent. Selectors without & apply to descendants of the parent, unless they start
+// with a colon, in which case they're pseudo-classes or pseudo-elements of the parent.
+func nestCSSSelector(parent, selector string) string {
+	var op []string
+	for _, p := range splitCSSSelectors(parent) {
+		for _, s := range splitCSSSelectors(selector) {
+			switch {
+			case strings.Contains(s, "&"):
+				op = append(op, strings.ReplaceAll(s, "&", p))
+			case strings.HasPrefix(s, ":"):
+				op = append(op, p+s)
+			default:
+				op = append(op, p+" "+s)
+			}
+		}
+	}
+	return strings.Join(op, ",")
+}
+
+// splitCSSSelectors splits a selector list on the commas that aren't within brackets,
+// e.g. "a, b:is(c, d)" is split into "a" and "b:is(c, d)".
+func splitCSSSelectors(s string) (op []string) {
+	var depth, start int
+	for i, c := range s {
+		switch c {
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				op = append(op, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(op, strings.TrimSpace(s[start:]))
+}
+
 func (g *generator) writeGoExpression(n parser.GoExpression) (err error) {
 	if _, err = g.w.WriteIndent(0, "// GoExpression\n"); err != nil {
 		return err
 	}
-	r, err := g.w.Write(n.Expression.Value)
+	r, err := g.w.Write(blank(n.Expression.Value, g.goExpressionBlanks[n.Expression.Range.From.Index]))
 	if err != nil {
 		return err
 	}
 	g.sourceMap.Add(n.Expression, r)
+	if g.goExpressionRanges == nil {
+		g.goExpressionRanges = make(map[int64]parser.Range)
+	}
+	g.goExpressionRanges[n.Expression.Range.From.Index] = r
 	if _, err = g.w.WriteIndent(0, "\n\n"); err != nil {
 		return err
 	}
 	return err
 }
 
+// blank replaces the given ranges of s with spaces, keeping line breaks so that the
+// positions of the remaining code are unchanged.
+func blank(s string, ranges [][2]int) string {
+	if len(ranges) == 0 {
+		return s
+	}
+	b := []byte(s)
+	for _, r := range ranges {
+		for i := r[0]; i < r[1] && i < len(b); i++ {
+			if b[i] != '\n' {
+				b[i] = ' '
+			}
+		}
+	}
+	return string(b)
+}
+
 func (g *generator) writeTemplBuffer(indentLevel int) (err error) {
 	// templBuffer, templIsBuffer := w.(*bytes.Buffer)
 	if _, err = g.w.WriteIndent(indentLevel, "templBuffer, templIsBuffer := w.(*bytes.Buffer)\n"); err != nil {
@@ -476,10 +933,14 @@ func (g *generator) writeNode(indentLevel int, current parser.Node) (err error)
 		err = g.writeSwitchExpression(indentLevel, n)
 	case parser.StringExpression:
 		err = g.writeStringExpression(indentLevel, n.Expression)
+	case parser.GoCode:
+		err = g.writeGoCode(indentLevel, n.Expression)
 	case parser.Whitespace:
 		err = g.writeWhitespace(indentLevel, n)
 	case parser.Text:
 		err = g.writeText(indentLevel, n)
+	case parser.Comment:
+		err = g.writeComment(indentLevel, n)
 	default:
 		_, err = g.w.Write(fmt.Sprintf("Unhandled type: %v\n", reflect.TypeOf(n)))
 	}
@@ -524,6 +985,28 @@ func (g *generator) writeIfExpression(indentLevel int, n parser.IfExpression) (e
 		}
 		indentLevel--
 	}
+	for _, elseIf := range n.ElseIfs {
+		// } else if
+		if _, err = g.w.WriteIndent(indentLevel, `} else if `); err != nil {
+			return err
+		}
+		// x == y
+		if r, err = g.w.Write(elseIf.Expression.Value); err != nil {
+			return err
+		}
+		g.sourceMap.Add(elseIf.Expression, r)
+		// {
+		if _, err = g.w.Write(` {` + "\n"); err != nil {
+			return err
+		}
+		{
+			indentLevel++
+			if err = g.writeNodes(indentLevel, n, stripLeadingAndTrailingWhitespace(elseIf.Then)); err != nil {
+				return err
+			}
+			indentLevel--
+		}
+	}
 	if len(n.Else) > 0 {
 		// } else {
 		if _, err = g.w.WriteIndent(indentLevel, `} else {`+"\n"); err != nil {
@@ -907,6 +1390,12 @@ func (g *generator) writeAttributesCSS(indentLevel int, attrs []parser.Attribute
 			if err != nil {
 				return err
 			}
+			for _, elseIf := range cattr.ElseIfs {
+				err = g.writeAttributesCSS(indentLevel, elseIf.Then)
+				if err != nil {
+					return err
+				}
+			}
 			err = g.writeAttributesCSS(indentLevel, cattr.Else)
 			if err != nil {
 				return err
@@ -925,8 +1414,7 @@ func (g *generator) writeElementScript(indentLevel int, n parser.Element) (err e
 	var scriptExpressions []string
 	for i := 0; i < len(n.Attributes); i++ {
 		if attr, ok := n.Attributes[i].(parser.ExpressionAttribute); ok {
-			name := html.EscapeString(attr.Name)
-			if strings.HasPrefix(name, "on") {
+			if parser.IsEventHandlerAttribute(attr.Name) {
 				scriptExpressions = append(scriptExpressions, attr.Expression.Value)
 			}
 		}
@@ -1043,7 +1531,7 @@ func (g *generator) writeExpressionAttribute(indentLevel int, elementName string
 			return err
 		}
 	} else {
-		if strings.HasPrefix(attr.Name, "on") {
+		if parser.IsEventHandlerAttribute(attr.Name) {
 			// It's a JavaScript handler, and requires special handling, because we expect a JavaScript expression.
 			vn := g.createVariableName()
 			// var vn templ.ComponentScript =
@@ -1095,6 +1583,78 @@ func (g *generator) writeExpressionAttribute(indentLevel int, elementName string
 	return nil
 }
 
+// urlAttributes contain URLs, so interpolated values are sanitized with templ.URL.
+var urlAttributes = map[string]struct{}{
+	"action": {}, "cite": {}, "formaction": {}, "href": {}, "poster": {}, "src": {},
+}
+
+func (g *generator) writeInterpolatedAttribute(indentLevel int, attr parser.InterpolatedAttribute) (err error) {
+	attrName := html.EscapeString(attr.Name)
+	// Name, and open quote.
+	if _, err = g.w.WriteIndent(indentLevel, fmt.Sprintf(`_, err = templBuffer.WriteString(" %s=\"")`+"\n", attrName)); err != nil {
+		return err
+	}
+	if err = g.writeErrorHandler(indentLevel); err != nil {
+		return err
+	}
+	// Convert each expression to a string.
+	values := make([]string, len(attr.Parts))
+	for i, p := range attr.Parts {
+		if !p.IsExpression() {
+			continue
+		}
+		values[i] = g.createVariableName()
+		// var vn string
+		if _, err = g.w.WriteIndent(indentLevel, "var "+values[i]+" string\n"); err != nil {
+			return err
+		}
+		if err = g.writeToString(indentLevel, values[i], p.Expression); err != nil {
+			return err
+		}
+		if err = g.writeErrorHandler(indentLevel); err != nil {
+			return err
+		}
+	}
+	if _, isURL := urlAttributes[strings.ToLower(attr.Name)]; isURL {
+		// URLs are sanitized once the parts have been joined, since a protocol could be made from several parts.
+		parts := make([]string, len(attr.Parts))
+		for i, p := range attr.Parts {
+			parts[i] = values[i]
+			if !p.IsExpression() {
+				parts[i] = createGoString(p.Value)
+			}
+		}
+		if _, err = g.w.WriteIndent(indentLevel, "_, err = templBuffer.WriteString(templ.EscapeString(string(templ.URL("+strings.Join(parts, " + ")+"))))\n"); err != nil {
+			return err
+		}
+		if err = g.writeErrorHandler(indentLevel); err != nil {
+			return err
+		}
+	} else {
+		for i, p := range attr.Parts {
+			if p.IsExpression() {
+				_, err = g.w.WriteIndent(indentLevel, "_, err = templBuffer.WriteString(templ.EscapeString("+values[i]+"))\n")
+			} else {
+				_, err = g.w.WriteIndent(indentLevel, "_, err = templBuffer.WriteString("+createGoString(html.EscapeString(p.Value))+")\n")
+			}
+			if err != nil {
+				return err
+			}
+			if err = g.writeErrorHandler(indentLevel); err != nil {
+				return err
+			}
+		}
+	}
+	// Close quote.
+	if _, err = g.w.WriteIndent(indentLevel, `_, err = templBuffer.WriteString("\"")`+"\n"); err != nil {
+		return err
+	}
+	if err = g.writeErrorHandler(indentLevel); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (g *generator) writeConditionalAttribute(indentLevel int, elementName string, attr parser.ConditionalAttribute) (err error) {
 	// if
 	if _, err = g.w.WriteIndent(indentLevel, `if `); err != nil {
@@ -1117,6 +1677,28 @@ func (g *generator) writeConditionalAttribute(indentLevel int, elementName strin
 		}
 		indentLevel--
 	}
+	for _, elseIf := range attr.ElseIfs {
+		// } else if
+		if _, err = g.w.WriteIndent(indentLevel, `} else if `); err != nil {
+			return err
+		}
+		// x == y
+		if r, err = g.w.Write(elseIf.Expression.Value); err != nil {
+			return err
+		}
+		g.sourceMap.Add(elseIf.Expression, r)
+		// {
+		if _, err = g.w.Write(` {` + "\n"); err != nil {
+			return err
+		}
+		{
+			indentLevel++
+			if err = g.writeElementAttributes(indentLevel, elementName, elseIf.Then); err != nil {
+				return err
+			}
+			indentLevel--
+		}
+	}
 	if len(attr.Else) > 0 {
 		// } else {
 		if _, err = g.w.WriteIndent(indentLevel, `} else {`+"\n"); err != nil {
@@ -1151,6 +1733,8 @@ func (g *generator) writeElementAttributes(indentLevel int, name string, attrs [
 			err = g.writeBoolExpressionAttribute(indentLevel, attr)
 		case parser.ExpressionAttribute:
 			err = g.writeExpressionAttribute(indentLevel, name, attr)
+		case parser.InterpolatedAttribute:
+			err = g.writeInterpolatedAttribute(indentLevel, attr)
 		case parser.ConditionalAttribute:
 			err = g.writeConditionalAttribute(indentLevel, name, attr)
 		default:
@@ -1214,17 +1798,18 @@ func (g *generator) writeStringExpression(indentLevel int, e parser.Expression)
 	if _, err = g.w.WriteIndent(indentLevel, "// StringExpression\n"); err != nil {
 		return err
 	}
-	var r parser.Range
 	vn := g.createVariableName()
-	// var vn string = sExpr
-	if _, err = g.w.WriteIndent(indentLevel, "var "+vn+" string = "); err != nil {
+	// var vn string
+	if _, err = g.w.WriteIndent(indentLevel, "var "+vn+" string\n"); err != nil {
 		return err
 	}
-	// p.Name()
-	if r, err = g.w.Write(e.Value + "\n"); err != nil {
+	// vn, err = templ.ToString(sExpr)
+	if err = g.writeToString(indentLevel, vn, e); err != nil {
+		return err
+	}
+	if err = g.writeErrorHandler(indentLevel); err != nil {
 		return err
 	}
-	g.sourceMap.Add(e, r)
 	// _, err = templBuffer.WriteString(vn)
 	if _, err = g.w.WriteIndent(indentLevel, "_, err = templBuffer.WriteString(templ.EscapeString("+vn+"))\n"); err != nil {
 		return err
@@ -1235,6 +1820,22 @@ func (g *generator) writeStringExpression(indentLevel int, e parser.Expression)
 	return nil
 }
 
+func (g *generator) writeGoCode(indentLevel int, e parser.Expression) (err error) {
+	if strings.TrimSpace(e.Value) == "" {
+		return
+	}
+	if _, err = g.w.WriteIndent(indentLevel, "// GoCode\n"); err != nil {
+		return err
+	}
+	// Write the statements as-is, so that the source map lines up.
+	var r parser.Range
+	if r, err = g.w.WriteIndent(indentLevel, e.Value+"\n"); err != nil {
+		return err
+	}
+	g.sourceMap.Add(e, r)
+	return nil
+}
+
 func (g *generator) writeWhitespace(indentLevel int, n parser.Whitespace) (err error) {
 	if len(n.Value) == 0 {
 		return
@@ -1271,6 +1872,24 @@ func (g *generator) writeText(indentLevel int, n parser.Text) (err error) {
 	return nil
 }
 
+func (g *generator) writeComment(indentLevel int, n parser.Comment) (err error) {
+	if n.Type != parser.CommentTypeHTML {
+		// templ comments are only in the source.
+		return nil
+	}
+	if _, err = g.w.WriteIndent(indentLevel, "// Comment\n"); err != nil {
+		return err
+	}
+	// _, err = templBuffer.WriteString("<!-- ... -->")
+	if _, err = g.w.WriteIndent(indentLevel, "_, err = templBuffer.WriteString("+createGoString("<!--"+n.Contents+"-->")+")\n"); err != nil {
+		return err
+	}
+	if err = g.writeErrorHandler(indentLevel); err != nil {
+		return err
+	}
+	return nil
+}
+
 func createGoString(s string) string {
 	var sb strings.Builder
 	sb.WriteRune('`')
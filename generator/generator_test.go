@@ -2,6 +2,7 @@ package generator
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/a-h/templ/parser/v2"
@@ -35,3 +36,198 @@ func TestGeneratorSourceMap(t *testing.T) {
 		t.Errorf("unexpected target:\n%v", diff)
 	}
 }
+
+func TestGeneratorSourceMapElseIf(t *testing.T) {
+	w := new(bytes.Buffer)
+	g := generator{
+		w:         NewRangeWriter(w),
+		sourceMap: parser.NewSourceMap(),
+	}
+	tf, err := parser.ParseString(`package main
+
+templ Name(a, b bool) {
+	if a {
+		A
+	} else if b {
+		B
+	}
+}
+`)
+	if err != nil {
+		t.Fatalf("failed to parse template: %v", err)
+	}
+	var n parser.IfExpression
+	parser.Inspect(tf, func(node any) bool {
+		if ifExpr, ok := node.(parser.IfExpression); ok {
+			n = ifExpr
+		}
+		return true
+	})
+	if err = g.writeIfExpression(0, n); err != nil {
+		t.Fatalf("failed to write if expression: %v", err)
+	}
+	// The "b" of "} else if b {" is on line 5, col 11 of the template.
+	lines := strings.Split(w.String(), "\n")
+	var line int
+	for i, l := range lines {
+		if strings.HasPrefix(l, "} else if ") {
+			line = i
+		}
+	}
+	actual, ok := g.sourceMap.TargetPositionFromSource(5, 11)
+	if !ok {
+		t.Fatalf("failed to get matching target")
+	}
+	if actual.Line != uint32(line) || actual.Col != 10 {
+		t.Errorf("expected the else if expression to be mapped to line %d, col 10, got %v\n%s", line, actual, w.String())
+	}
+}
+
+func TestGeneratorSourceMapGoCode(t *testing.T) {
+	w := new(bytes.Buffer)
+	g := generator{
+		w:         NewRangeWriter(w),
+		sourceMap: parser.NewSourceMap(),
+	}
+	tf, err := parser.ParseString(`package main
+
+templ Name(items []int) {
+	{{
+		total := 0
+		for _, item := range items {
+			total += item
+		}
+	}}
+}
+`)
+	if err != nil {
+		t.Fatalf("failed to parse template: %v", err)
+	}
+	var n parser.GoCode
+	parser.Inspect(tf, func(node any) bool {
+		if gc, ok := node.(parser.GoCode); ok {
+			n = gc
+		}
+		return true
+	})
+	if err = g.writeGoCode(0, n.Expression); err != nil {
+		t.Fatalf("failed to write go code: %v", err)
+	}
+	// The "total" of "total += item" is on line 6, col 3 of the template.
+	lines := strings.Split(w.String(), "\n")
+	var line int
+	for i, l := range lines {
+		if strings.Contains(l, "total += item") {
+			line = i
+		}
+	}
+	actual, ok := g.sourceMap.TargetPositionFromSource(6, 3)
+	if !ok {
+		t.Fatalf("failed to get matching target")
+	}
+	if actual.Line != uint32(line) || actual.Col != uint32(strings.Index(lines[line], "total")) {
+		t.Errorf("expected the statement to be mapped to line %d, got %v\n%s", line, actual, w.String())
+	}
+}
+
+func TestGeneratorSourceMapInterpolatedAttribute(t *testing.T) {
+	w := new(bytes.Buffer)
+	g := generator{
+		w:         NewRangeWriter(w),
+		sourceMap: parser.NewSourceMap(),
+	}
+	tf, err := parser.ParseString(`package main
+
+templ Name(id, tab string) {
+	<a href="/users/{ id }/edit#{ tab }">Edit</a>
+}
+`)
+	if err != nil {
+		t.Fatalf("failed to parse template: %v", err)
+	}
+	var n parser.InterpolatedAttribute
+	parser.Inspect(tf, func(node any) bool {
+		if attr, ok := node.(parser.InterpolatedAttribute); ok {
+			n = attr
+		}
+		return true
+	})
+	if err = g.writeInterpolatedAttribute(0, n); err != nil {
+		t.Fatalf("failed to write interpolated attribute: %v", err)
+	}
+	// The "tab" expression is on line 3, col 31 of the template.
+	lines := strings.Split(w.String(), "\n")
+	var line int
+	for i, l := range lines {
+		if strings.HasSuffix(l, "templ.ToString(tab)") {
+			line = i
+		}
+	}
+	actual, ok := g.sourceMap.TargetPositionFromSource(3, 31)
+	if !ok {
+		t.Fatalf("failed to get matching target")
+	}
+	if actual.Line != uint32(line) || actual.Col != uint32(strings.Index(lines[line], "tab")) {
+		t.Errorf("expected the expression to be mapped to line %d, got %v\n%s", line, actual, w.String())
+	}
+}
+
+func TestGeneratorSourceMapTypeParameters(t *testing.T) {
+	src := `package main
+
+templ List[T any](items []T) {
+	<ul></ul>
+}
+
+templ Page(names []string) {
+	@List[string](names)
+}
+`
+	tf, err := parser.ParseString(src)
+	if err != nil {
+		t.Fatalf("failed to parse template: %v", err)
+	}
+	w := new(bytes.Buffer)
+	sm, err := Generate(tf, w)
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+	srcLines := strings.Split(src, "\n")
+	lines := strings.Split(w.String(), "\n")
+	tests := []struct {
+		name      string
+		line, col uint32
+		target    string
+	}{
+		{
+			// The "T" of "[]T" is on line 2, col 26 of the template.
+			name:   "type parameter in the signature",
+			line:   2,
+			col:    26,
+			target: "func List[T any](items []T)",
+		},
+		{
+			// The "string" of "@List[string]" is on line 7, col 7 of the template.
+			name:   "type argument at the call site",
+			line:   7,
+			col:    7,
+			target: "List[string](names)",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			actual, ok := sm.TargetPositionFromSource(tt.line, tt.col)
+			if !ok {
+				t.Fatalf("failed to get matching target")
+			}
+			l := lines[actual.Line]
+			if !strings.Contains(l, tt.target) {
+				t.Fatalf("expected line %d to contain %q, got %q", actual.Line, tt.target, l)
+			}
+			if expected, actual := srcLines[tt.line][tt.col:tt.col+1], l[actual.Col:actual.Col+1]; expected != actual {
+				t.Errorf("expected the source to map to %q, got %q in %q", expected, actual, l)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package imports
+
+import (
+	"path/filepath"
+	"sync"
+)
+
+// NewResolverCache creates an empty ResolverCache.
+func NewResolverCache() *ResolverCache {
+	return &ResolverCache{
+		resolvers: make(map[string]*Resolver),
+	}
+}
+
+// ResolverCache shares a Resolver between the templ files in each package, so that the
+// module is only walked once per package.
+type ResolverCache struct {
+	m         sync.Mutex
+	resolvers map[string]*Resolver
+}
+
+// Get the Resolver for the package that contains the templ file, creating it if needed.
+func (c *ResolverCache) Get(templFileName string) *Resolver {
+	dir := packageDir(templFileName)
+	c.m.Lock()
+	defer c.m.Unlock()
+	r, ok := c.resolvers[dir]
+	if !ok {
+		r = NewResolver(dir)
+		c.resolvers[dir] = r
+	}
+	return r
+}
+
+// Delete the Resolver for the package that contains the file, so that changes to the
+// package are picked up.
+func (c *ResolverCache) Delete(fileName string) {
+	dir := packageDir(fileName)
+	c.m.Lock()
+	defer c.m.Unlock()
+	delete(c.resolvers, dir)
+}
+
+// Clear all of the resolvers, e.g. when files in the module have changed.
+func (c *ResolverCache) Clear() {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.resolvers = make(map[string]*Resolver)
+}
+
+func packageDir(fileName string) string {
+	if fileName == "" {
+		return ""
+	}
+	dir := filepath.Dir(fileName)
+	if abs, err := filepath.Abs(dir); err == nil {
+		return abs
+	}
+	return dir
+}
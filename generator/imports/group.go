@@ -0,0 +1,138 @@
+package imports
+
+import (
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/a-h/templ/parser/v2"
+)
+
+// Group sorts the import declarations at the start of a fragment of Go code, and
+// groups them into standard library imports, followed by all other imports.
+//
+// The fragment must not include a package clause. Imports that have comments
+// attached are left as they are, since moving them could lose the comments.
+func Group(code string) (string, error) {
+	const prefix = "package p\n"
+	src := prefix + code
+	fset := token.NewFileSet()
+	f, err := goparser.ParseFile(fset, "", src, goparser.ImportsOnly|goparser.ParseComments)
+	if err != nil {
+		return code, err
+	}
+	var specs []Import
+	var from, to int
+	for i, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.IMPORT {
+			break
+		}
+		if i == 0 {
+			from = fset.Position(gd.Pos()).Offset
+		}
+		to = fset.Position(gd.End()).Offset
+		for _, spec := range gd.Specs {
+			is := spec.(*ast.ImportSpec)
+			imp := Import{}
+			if imp.Path, err = strconv.Unquote(is.Path.Value); err != nil {
+				return code, err
+			}
+			if is.Name != nil {
+				imp.Name = is.Name.Name
+			}
+			specs = append(specs, imp)
+		}
+	}
+	if len(specs) == 0 {
+		return code, nil
+	}
+	for _, cg := range f.Comments {
+		if offset := fset.Position(cg.Pos()).Offset; offset > from && offset < to {
+			return code, nil
+		}
+	}
+	return src[len(prefix):from] + formatImports(specs) + src[to:], nil
+}
+
+// formatImports writes a single import declaration for the specs.
+func formatImports(specs []Import) string {
+	specs = dedupe(specs)
+	if len(specs) == 1 {
+		return "import " + specs[0].String()
+	}
+	var std, other []Import
+	for _, imp := range specs {
+		if IsStdlib(imp.Path) {
+			std = append(std, imp)
+			continue
+		}
+		other = append(other, imp)
+	}
+	var sb strings.Builder
+	sb.WriteString("import (\n")
+	for i, group := range [][]Import{std, other} {
+		if len(group) == 0 {
+			continue
+		}
+		if i > 0 && len(std) > 0 {
+			sb.WriteString("\n")
+		}
+		sort.SliceStable(group, func(i, j int) bool {
+			if group[i].Path != group[j].Path {
+				return group[i].Path < group[j].Path
+			}
+			return group[i].Name < group[j].Name
+		})
+		for _, imp := range group {
+			sb.WriteString("\t" + imp.String() + "\n")
+		}
+	}
+	sb.WriteString(")")
+	return sb.String()
+}
+
+func dedupe(specs []Import) (op []Import) {
+	seen := make(map[Import]struct{})
+	for _, imp := range specs {
+		if _, ok := seen[imp]; ok {
+			continue
+		}
+		seen[imp] = struct{}{}
+		op = append(op, imp)
+	}
+	return op
+}
+
+// IsStdlib returns true if the import path is part of the Go standard library.
+// Like goimports, standard library paths are identified by not having a dot in
+// the first path element.
+func IsStdlib(importPath string) bool {
+	first, _, _ := strings.Cut(importPath, "/")
+	return !strings.Contains(first, ".")
+}
+
+// GroupTemplateFile sorts and groups the imports at the start of a templ file.
+func GroupTemplateFile(tf parser.TemplateFile) parser.TemplateFile {
+	if len(tf.Nodes) == 0 {
+		return tf
+	}
+	// Imports have to come before any other declarations, so only the first node needs checking.
+	ge, ok := tf.Nodes[0].(parser.GoExpression)
+	if !ok {
+		return tf
+	}
+	code, err := Group(ge.Expression.Value)
+	if err != nil {
+		return tf
+	}
+	ge.Expression.Value = code
+	nodes := make([]parser.TemplateFileNode, len(tf.Nodes))
+	copy(nodes, tf.Nodes)
+	nodes[0] = ge
+	tf.Nodes = nodes
+	return tf
+}
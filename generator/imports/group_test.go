@@ -0,0 +1,75 @@
+package imports
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGroup(t *testing.T) {
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name: "imports are sorted and grouped",
+			input: `import "github.com/a-h/templ"
+import "strings"
+import (
+	"fmt"
+	p "github.com/a-h/parse"
+)
+
+const x = 1`,
+			expected: `import (
+	"fmt"
+	"strings"
+
+	p "github.com/a-h/parse"
+	"github.com/a-h/templ"
+)
+
+const x = 1`,
+		},
+		{
+			name:     "single imports are left alone",
+			input:    `import "strings"`,
+			expected: `import "strings"`,
+		},
+		{
+			name: "duplicate imports are removed",
+			input: `import "strings"
+import "strings"`,
+			expected: `import "strings"`,
+		},
+		{
+			name: "imports with comments are not changed",
+			input: `import (
+	"strings" // Used to uppercase.
+	"fmt"
+)`,
+			expected: `import (
+	"strings" // Used to uppercase.
+	"fmt"
+)`,
+		},
+		{
+			name:     "code without imports is not changed",
+			input:    `const x = 1`,
+			expected: `const x = 1`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := Group(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+// Package imports works out which imports Go code generated from templ files needs,
+// in the same way as goimports, but using only GOROOT and the local module cache.
+package imports
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+)
+
+// Import is an import spec within Go source code.
+type Import struct {
+	// Name is the explicit name of the import, or empty.
+	Name string
+	// Path is the import path.
+	Path string
+	// PackageName is the name used to refer to the import within the code.
+	PackageName string
+	// Offset and End are the byte offsets of the code that needs to be removed to
+	// remove the import.
+	Offset, End int
+	// Used is true if the import is referenced.
+	Used bool
+}
+
+// String returns the import spec, e.g. `strs "strings"`.
+func (i Import) String() string {
+	if i.Name != "" {
+		return i.Name + " " + strconv.Quote(i.Path)
+	}
+	return strconv.Quote(i.Path)
+}
+
+// Result of analysing Go source code.
+type Result struct {
+	// Imports within the source code.
+	Imports []Import
+	// Missing imports, resolved from package references.
+	Missing []Import
+	// Unresolved package names that couldn't be found.
+	Unresolved []string
+}
+
+// Unused returns the imports that aren't referenced by the code.
+func (r Result) Unused() (unused []Import) {
+	for _, imp := range r.Imports {
+		if !imp.Used {
+			unused = append(unused, imp)
+		}
+	}
+	return unused
+}
+
+// Changed returns true if imports need to be added or removed.
+func (r Result) Changed() bool {
+	return len(r.Missing) > 0 || len(r.Unused()) > 0
+}
+
+// Analyze the Go source code generated from the templ file to find missing and unused imports.
+//
+// If the code can't be parsed, an empty result is returned so that the Go compiler
+// can report the problem.
+func (r *Resolver) Analyze(templFileName string, src []byte) (result Result, err error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, 0)
+	if err != nil {
+		return result, nil
+	}
+	refs := packageReferences(f)
+
+	imported := make(map[string]struct{})
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.IMPORT {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			is := spec.(*ast.ImportSpec)
+			imp := Import{}
+			if imp.Path, err = strconv.Unquote(is.Path.Value); err != nil {
+				return result, err
+			}
+			if is.Name != nil {
+				imp.Name = is.Name.Name
+			}
+			imp.PackageName = imp.Name
+			if imp.PackageName == "" {
+				imp.PackageName = r.PackageName(imp.Path)
+			}
+			switch {
+			case imp.Name == "_" || imp.Name == "." || imp.Path == "C":
+				// Side effects, dot imports and cgo can't be checked.
+				imp.Used = true
+			default:
+				_, imp.Used = refs[imp.PackageName]
+			}
+			// Unparenthesised imports are removed as a whole, since an import keyword
+			// without a spec isn't valid.
+			from, to := is.Pos(), is.End()
+			if !gd.Lparen.IsValid() {
+				from, to = gd.Pos(), gd.End()
+			}
+			imp.Offset, imp.End = fset.Position(from).Offset, fset.Position(to).Offset
+			result.Imports = append(result.Imports, imp)
+			imported[imp.PackageName] = struct{}{}
+		}
+	}
+
+	// Find the names that need to be imported.
+	decls := r.PackageDecls(templFileName)
+	names := make([]string, 0, len(refs))
+	for name := range refs {
+		if _, ok := imported[name]; ok {
+			continue
+		}
+		if _, ok := decls[name]; ok {
+			continue
+		}
+		if f.Scope.Lookup(name) != nil {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		importPath, ok := r.Resolve(name, refs[name])
+		if !ok {
+			result.Unresolved = append(result.Unresolved, name)
+			continue
+		}
+		imp := Import{
+			Path:        importPath,
+			PackageName: name,
+			Used:        true,
+		}
+		if guessPackageName(importPath) != name {
+			imp.Name = name
+		}
+		result.Missing = append(result.Missing, imp)
+	}
+	return result, nil
+}
+
+// packageReferences finds the identifiers used on the left of a selector expression
+// that aren't declared locally, e.g. strings.ToUpper, and the symbols used.
+func packageReferences(f *ast.File) (refs map[string][]string) {
+	refs = make(map[string][]string)
+	ast.Inspect(f, func(n ast.Node) bool {
+		se, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		id, ok := se.X.(*ast.Ident)
+		if !ok || id.Obj != nil {
+			return true
+		}
+		if !containsString(refs[id.Name], se.Sel.Name) {
+			refs[id.Name] = append(refs[id.Name], se.Sel.Name)
+		}
+		return true
+	})
+	return refs
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,248 @@
+package imports
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAnalyze(t *testing.T) {
+	var tests = []struct {
+		name            string
+		input           string
+		expectedMissing []string
+		expectedUnused  []string
+	}{
+		{
+			name: "missing standard library packages are resolved",
+			input: `package test
+
+func f(s string) string {
+	return strings.ToUpper(s) + strconv.Itoa(1)
+}`,
+			expectedMissing: []string{`"strconv"`, `"strings"`},
+		},
+		{
+			name: "packages are chosen based on the symbols used",
+			input: `package test
+
+func f() int {
+	return rand.Intn(10)
+}`,
+			expectedMissing: []string{`"math/rand"`},
+		},
+		{
+			name: "unused imports are reported",
+			input: `package test
+
+import "fmt"
+import (
+	"strings"
+	str "strconv"
+)
+
+func f(s string) string {
+	return strings.ToUpper(s)
+}`,
+			expectedUnused: []string{`"fmt"`, `str "strconv"`},
+		},
+		{
+			name: "local variables are not packages",
+			input: `package test
+
+type data struct {
+	Name string
+}
+
+func f(strings data) string {
+	return strings.Name
+}`,
+		},
+		{
+			name: "package level declarations are not packages",
+			input: `package test
+
+var strings = struct{ Name string }{}
+
+func f() string {
+	return strings.Name
+}`,
+		},
+		{
+			name: "blank and dot imports are never removed",
+			input: `package test
+
+import _ "embed"
+import . "strings"
+`,
+		},
+		{
+			name: "unknown packages are not imported",
+			input: `package test
+
+func f() string {
+	return notapackage.Name
+}`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := NewResolver("").Analyze("", []byte(tt.input))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var missing, unused []string
+			for _, imp := range actual.Missing {
+				missing = append(missing, imp.String())
+			}
+			for _, imp := range actual.Unused() {
+				unused = append(unused, imp.String())
+			}
+			if diff := cmp.Diff(tt.expectedMissing, missing); diff != "" {
+				t.Errorf("unexpected missing imports:\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.expectedUnused, unused); diff != "" {
+				t.Errorf("unexpected unused imports:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAnalyzeUnusedImportRange(t *testing.T) {
+	input := `package test
+
+import "fmt"
+import (
+	"strings"
+)
+`
+	actual, err := NewResolver("").Analyze("", []byte(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var removed []string
+	for _, imp := range actual.Unused() {
+		removed = append(removed, input[imp.Offset:imp.End])
+	}
+	expected := []string{`import "fmt"`, `"strings"`}
+	if diff := cmp.Diff(expected, removed); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestGuessPackageName(t *testing.T) {
+	var tests = []struct {
+		input    string
+		expected string
+	}{
+		{input: "strings", expected: "strings"},
+		{input: "net/http", expected: "http"},
+		{input: "github.com/a-h/templ", expected: "templ"},
+		{input: "github.com/a-h/go-thing/v2", expected: "thing"},
+		{input: "gopkg.in/yaml.v3", expected: "yaml"},
+	}
+	for _, tt := range tests {
+		if actual := guessPackageName(tt.input); actual != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, actual)
+		}
+	}
+}
+
+func TestPackageDecls(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.go":       "package test\n\nvar strings = 1\n",
+		"a_templ.go": "package test\n\nfunc A() {}\n",
+		"b_templ.go": "package test\n\nfunc B() {}\n",
+	}
+	for name, contents := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+	r := NewResolverCache().Get(filepath.Join(dir, "a.templ"))
+	tests := []struct {
+		templFileName string
+		expected      []string
+	}{
+		{templFileName: filepath.Join(dir, "a.templ"), expected: []string{"B", "strings"}},
+		{templFileName: filepath.Join(dir, "b.templ"), expected: []string{"A", "strings"}},
+	}
+	for _, tt := range tests {
+		var actual []string
+		for name := range r.PackageDecls(tt.templFileName) {
+			actual = append(actual, name)
+		}
+		sort.Strings(actual)
+		if diff := cmp.Diff(tt.expected, actual); diff != "" {
+			t.Errorf("%s:\n%s", tt.templFileName, diff)
+		}
+	}
+}
+
+func TestResolverCache(t *testing.T) {
+	dir := t.TempDir()
+	c := NewResolverCache()
+	a := c.Get(filepath.Join(dir, "a.templ"))
+	if b := c.Get(filepath.Join(dir, "b.templ")); a != b {
+		t.Error("expected templ files in the same package to share a resolver")
+	}
+	if other := c.Get(filepath.Join(dir, "sub", "a.templ")); a == other {
+		t.Error("expected templ files in different packages to have different resolvers")
+	}
+	c.Delete(filepath.Join(dir, "a.go"))
+	if b := c.Get(filepath.Join(dir, "b.templ")); a == b {
+		t.Error("expected a new resolver after the package's resolver was deleted")
+	}
+}
+
+func TestTypesOf(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":     "module example.com/test\n\ngo 1.20\n",
+		"types.go":   "package test\n\ntype Address struct{}\n",
+		"a_templ.go": "package test\n\nfunc A() string { return \"old\" }\n",
+	}
+	for name, contents := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+	src := `package test
+
+import "time"
+
+func A(a *Address) {
+	_ = fmt(a)
+	_ = fmt( time.Now() )
+	_ = fmt(time.ParseDuration("1s"))
+	_ = fmt(notDeclared)
+}
+
+func fmt(v any, errs ...error) string { return "" }
+`
+	exprs := []string{"a", " time.Now() ", `time.ParseDuration("1s")`, "notDeclared"}
+	var ranges [][2]int
+	for _, expr := range exprs {
+		from := strings.Index(src, "fmt("+expr+")") + len("fmt(")
+		ranges = append(ranges, [2]int{from, from + len(expr)})
+	}
+	r := NewResolverCache().Get(filepath.Join(dir, "a.templ"))
+	var actual []string
+	for _, typ := range r.TypesOf(filepath.Join(dir, "a.templ"), []byte(src), ranges) {
+		if typ == nil {
+			actual = append(actual, "")
+			continue
+		}
+		actual = append(actual, typ.String())
+	}
+	expected := []string{"*test.Address", "time.Time", "time.Duration", ""}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Error(diff)
+	}
+}
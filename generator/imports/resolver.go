@@ -0,0 +1,498 @@
+package imports
+
+import (
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+// NewResolver creates a Resolver for the Go package in the given directory. Walking the
+// module is expensive, so a Resolver should be shared between the files in a package,
+// see ResolverCache.
+//
+// Packages are resolved from GOROOT, the enclosing module, and the module's
+// requirements in the local module cache. The network is never used. If the
+// directory is empty, only GOROOT is used.
+func NewResolver(dir string) *Resolver {
+	r := &Resolver{
+		goroot: build.Default.GOROOT,
+	}
+	if dir == "" {
+		return r
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return r
+	}
+	r.dir = abs
+	r.modRoot, r.modFile = findModule(r.dir)
+	return r
+}
+
+// Resolver finds the import paths of packages that are referenced by name.
+type Resolver struct {
+	goroot  string
+	dir     string
+	modRoot string
+	modFile *modfile.File
+
+	indexOnce sync.Once
+	index     map[string][]candidate
+
+	declsOnce sync.Once
+	// decls maps the names of the Go files in the package to their top level declarations.
+	decls map[string][]string
+
+	typesMutex sync.Mutex
+	importer   types.Importer
+	// exports maps import paths to the files that contain their export data.
+	exports map[string]string
+}
+
+type candidate struct {
+	Path string
+	Dir  string
+	// Rank is used to order candidates, lower is better.
+	Rank int
+}
+
+const (
+	rankStdlib = iota
+	rankModule
+	rankRequirement
+)
+
+// Resolve finds the import path of the package with the given name that exports
+// all of the given symbols.
+func (r *Resolver) Resolve(name string, symbols []string) (importPath string, ok bool) {
+	r.indexOnce.Do(r.buildIndex)
+	for _, c := range r.index[name] {
+		if exportsAll(c.Dir, symbols) {
+			return c.Path, true
+		}
+	}
+	return "", false
+}
+
+// PackageName returns the name of the package at the given import path, falling back
+// to the last element of the path if the package can't be found locally.
+func (r *Resolver) PackageName(importPath string) string {
+	if dir, ok := r.dirOf(importPath); ok {
+		if name, ok := readPackageName(dir); ok {
+			return name
+		}
+	}
+	return guessPackageName(importPath)
+}
+
+// PackageDecls returns the names declared at the top level of the Go files in the package,
+// since they don't need to be imported. The code previously generated from the templ file
+// is ignored, since it's being replaced.
+func (r *Resolver) PackageDecls(templFileName string) map[string]struct{} {
+	r.declsOnce.Do(r.readDecls)
+	var generated string
+	if templFileName != "" {
+		if abs, err := filepath.Abs(templFileName); err == nil {
+			generated = generatedFileName(abs)
+		}
+	}
+	decls := make(map[string]struct{})
+	for fileName, names := range r.decls {
+		if fileName == generated {
+			continue
+		}
+		for _, name := range names {
+			decls[name] = struct{}{}
+		}
+	}
+	return decls
+}
+
+func (r *Resolver) readDecls() {
+	r.decls = make(map[string][]string)
+	if r.dir == "" {
+		return
+	}
+	entries, err := os.ReadDir(r.dir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		if e.IsDir() || !isPackageFile(e.Name()) {
+			continue
+		}
+		fileName := filepath.Join(r.dir, e.Name())
+		f, err := parser.ParseFile(token.NewFileSet(), fileName, nil, parser.SkipObjectResolution)
+		if err != nil {
+			continue
+		}
+		r.decls[fileName] = topLevelNames(f)
+	}
+}
+
+func generatedFileName(templFileName string) string {
+	return strings.TrimSuffix(templFileName, ".templ") + "_templ.go"
+}
+
+func (r *Resolver) dirOf(importPath string) (dir string, ok bool) {
+	if dir = filepath.Join(r.goroot, "src", filepath.FromSlash(importPath)); isDir(dir) {
+		return dir, true
+	}
+	if r.modFile == nil || r.modFile.Module == nil {
+		return "", false
+	}
+	if rel, isInModule := trimPathPrefix(importPath, r.modFile.Module.Mod.Path); isInModule {
+		dir = filepath.Join(r.modRoot, filepath.FromSlash(rel))
+		return dir, isDir(dir)
+	}
+	for _, req := range r.modFile.Require {
+		if rel, isInModule := trimPathPrefix(importPath, req.Mod.Path); isInModule {
+			root, ok := r.moduleDir(req.Mod)
+			if !ok {
+				return "", false
+			}
+			dir = filepath.Join(root, filepath.FromSlash(rel))
+			return dir, isDir(dir)
+		}
+	}
+	return "", false
+}
+
+// moduleDir finds the location of a required module, taking replace directives into account.
+func (r *Resolver) moduleDir(m module.Version) (dir string, ok bool) {
+	for _, rep := range r.modFile.Replace {
+		if rep.Old.Path != m.Path || (rep.Old.Version != "" && rep.Old.Version != m.Version) {
+			continue
+		}
+		if rep.New.Version == "" {
+			// Local directory replacement.
+			dir = rep.New.Path
+			if !filepath.IsAbs(dir) {
+				dir = filepath.Join(r.modRoot, dir)
+			}
+			return dir, isDir(dir)
+		}
+		m = rep.New
+		break
+	}
+	escapedPath, err := module.EscapePath(m.Path)
+	if err != nil {
+		return "", false
+	}
+	escapedVersion, err := module.EscapeVersion(m.Version)
+	if err != nil {
+		return "", false
+	}
+	dir = filepath.Join(modCache(), filepath.FromSlash(escapedPath)+"@"+escapedVersion)
+	return dir, isDir(dir)
+}
+
+func (r *Resolver) buildIndex() {
+	r.index = make(map[string][]candidate)
+	for _, c := range stdlibCandidates(r.goroot) {
+		r.index[c.name] = append(r.index[c.name], c.candidate)
+	}
+	if r.modFile != nil && r.modFile.Module != nil {
+		r.addModule(r.modFile.Module.Mod.Path, r.modRoot, rankModule)
+		for _, req := range r.modFile.Require {
+			if dir, ok := r.moduleDir(req.Mod); ok {
+				r.addModule(req.Mod.Path, dir, rankRequirement)
+			}
+		}
+	}
+	for name := range r.index {
+		sortCandidates(r.index[name])
+	}
+}
+
+func (r *Resolver) addModule(modulePath, root string, rank int) {
+	var packages []namedCandidate
+	if rank == rankModule {
+		// The main module is being edited, so it can't be cached.
+		packages = walkPackages(root, modulePath, rank, true)
+	} else {
+		packages = cachedWalkPackages(root, modulePath, rank)
+	}
+	for _, c := range packages {
+		r.index[c.name] = append(r.index[c.name], c.candidate)
+	}
+}
+
+func sortCandidates(candidates []candidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Rank != candidates[j].Rank {
+			return candidates[i].Rank < candidates[j].Rank
+		}
+		if len(candidates[i].Path) != len(candidates[j].Path) {
+			return len(candidates[i].Path) < len(candidates[j].Path)
+		}
+		return candidates[i].Path < candidates[j].Path
+	})
+}
+
+type namedCandidate struct {
+	name string
+	candidate
+}
+
+var packageCache = struct {
+	sync.Mutex
+	m map[string][]namedCandidate
+}{
+	m: make(map[string][]namedCandidate),
+}
+
+// stdlibCandidates returns the packages in GOROOT.
+func stdlibCandidates(goroot string) []namedCandidate {
+	return cachedWalkPackages(filepath.Join(goroot, "src"), "", rankStdlib)
+}
+
+// cachedWalkPackages walks the packages of the standard library, or of modules in the module
+// cache. Their contents don't change while the program is running, so the result is shared
+// between resolvers.
+func cachedWalkPackages(root, modulePath string, rank int) []namedCandidate {
+	packageCache.Lock()
+	defer packageCache.Unlock()
+	if c, ok := packageCache.m[root]; ok {
+		return c
+	}
+	c := walkPackages(root, modulePath, rank, false)
+	packageCache.m[root] = c
+	return c
+}
+
+// walkPackages finds all importable packages within a module root.
+func walkPackages(root, modulePath string, rank int, allowInternal bool) (packages []namedCandidate) {
+	_ = filepath.WalkDir(root, func(dir string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, dir)
+		if err != nil {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		if dir != root {
+			name := d.Name()
+			if name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return filepath.SkipDir
+			}
+			if name == "internal" && !allowInternal {
+				return filepath.SkipDir
+			}
+			if modulePath == "" && rel == "cmd" {
+				// The Go toolchain's own commands are not importable.
+				return filepath.SkipDir
+			}
+			if modulePath != "" && fileExists(filepath.Join(dir, "go.mod")) {
+				// Nested modules are separate modules.
+				return filepath.SkipDir
+			}
+		}
+		name, ok := readPackageName(dir)
+		if !ok || name == "main" || strings.HasSuffix(name, "_test") {
+			return nil
+		}
+		importPath := rel
+		if modulePath != "" {
+			importPath = path.Join(modulePath, rel)
+		}
+		if importPath == "." || importPath == "" {
+			return nil
+		}
+		packages = append(packages, namedCandidate{
+			name: name,
+			candidate: candidate{
+				Path: importPath,
+				Dir:  dir,
+				Rank: rank,
+			},
+		})
+		return nil
+	})
+	return packages
+}
+
+// readPackageName reads the package clause of the first Go file in the directory.
+func readPackageName(dir string) (name string, ok bool) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", false
+	}
+	for _, e := range entries {
+		if e.IsDir() || !isPackageFile(e.Name()) {
+			continue
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, e.Name()), nil, parser.PackageClauseOnly)
+		if err != nil {
+			continue
+		}
+		if f.Name.Name == "documentation" {
+			// Some directories contain a documentation-only file.
+			continue
+		}
+		return f.Name.Name, true
+	}
+	return "", false
+}
+
+// exportsAll returns true if the package in the directory exports all of the symbols.
+func exportsAll(dir string, symbols []string) bool {
+	if len(symbols) == 0 {
+		return true
+	}
+	exported := exportedNames(dir)
+	for _, s := range symbols {
+		if _, ok := exported[s]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+var exportedNamesCache = struct {
+	sync.Mutex
+	m map[string]map[string]struct{}
+}{
+	m: make(map[string]map[string]struct{}),
+}
+
+func exportedNames(dir string) map[string]struct{} {
+	exportedNamesCache.Lock()
+	defer exportedNamesCache.Unlock()
+	if names, ok := exportedNamesCache.m[dir]; ok {
+		return names
+	}
+	names := make(map[string]struct{})
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		if e.IsDir() || !isPackageFile(e.Name()) {
+			continue
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, e.Name()), nil, parser.SkipObjectResolution)
+		if err != nil {
+			continue
+		}
+		for _, name := range topLevelNames(f) {
+			if ast.IsExported(name) {
+				names[name] = struct{}{}
+			}
+		}
+	}
+	exportedNamesCache.m[dir] = names
+	return names
+}
+
+func topLevelNames(f *ast.File) (names []string) {
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil {
+				names = append(names, d.Name.Name)
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					names = append(names, s.Name.Name)
+				case *ast.ValueSpec:
+					for _, n := range s.Names {
+						names = append(names, n.Name)
+					}
+				}
+			}
+		}
+	}
+	return names
+}
+
+// guessPackageName uses the same heuristic as goimports, e.g. github.com/a-h/go-thing/v2 becomes thing.
+func guessPackageName(importPath string) string {
+	name := path.Base(importPath)
+	if strings.HasPrefix(name, "v") && isDigits(name[1:]) && path.Dir(importPath) != "." {
+		name = path.Base(path.Dir(importPath))
+	}
+	name = strings.TrimPrefix(name, "go-")
+	name = strings.TrimSuffix(name, "-go")
+	if i := strings.IndexAny(name, ".-"); i > 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isPackageFile(name string) bool {
+	return strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go") && !strings.HasPrefix(name, ".") && !strings.HasPrefix(name, "_")
+}
+
+func findModule(dir string) (root string, f *modfile.File) {
+	for {
+		fileName := filepath.Join(dir, "go.mod")
+		if data, err := os.ReadFile(fileName); err == nil {
+			f, err = modfile.ParseLax(fileName, data, nil)
+			if err != nil {
+				return "", nil
+			}
+			return dir, f
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+func modCache() string {
+	if dir := os.Getenv("GOMODCACHE"); dir != "" {
+		return dir
+	}
+	gopath := filepath.SplitList(build.Default.GOPATH)
+	if len(gopath) == 0 {
+		return ""
+	}
+	return filepath.Join(gopath[0], "pkg", "mod")
+}
+
+func trimPathPrefix(importPath, prefix string) (rel string, ok bool) {
+	if importPath == prefix {
+		return "", true
+	}
+	if strings.HasPrefix(importPath, prefix+"/") {
+		return importPath[len(prefix)+1:], true
+	}
+	return "", false
+}
+
+func isDir(name string) bool {
+	info, err := os.Stat(name)
+	return err == nil && info.IsDir()
+}
+
+func fileExists(name string) bool {
+	_, err := os.Stat(name)
+	return err == nil
+}
@@ -0,0 +1,186 @@
+package imports
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TypesOf type-checks the Go code generated from the templ file, along with the other Go files
+// in the package, and returns the types of the expressions at the given byte ranges of src.
+// The type of an expression that returns several values is the type of its first value.
+//
+// Type checking is best effort. If a type can't be found, e.g. because the package doesn't
+// compile, or the go command isn't available to find its dependencies, the type is nil.
+func (r *Resolver) TypesOf(templFileName string, src []byte, ranges [][2]int) (results []types.Type) {
+	results = make([]types.Type, len(ranges))
+	if r.dir == "" || len(ranges) == 0 {
+		return results
+	}
+	r.typesMutex.Lock()
+	defer r.typesMutex.Unlock()
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, 0)
+	if err != nil {
+		return results
+	}
+	files := append([]*ast.File{f}, r.packageFiles(fset, templFileName, f.Name.Name)...)
+	if err = r.exportData(files); err != nil {
+		return results
+	}
+	if r.importer == nil {
+		r.importer = importer.ForCompiler(token.NewFileSet(), "gc", r.openExportData)
+	}
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+	}
+	conf := types.Config{
+		Importer:    r.importer,
+		FakeImportC: true,
+		// Carry on after errors, so that the types of the other expressions are found.
+		Error: func(err error) {},
+	}
+	_, _ = conf.Check(f.Name.Name, fset, files, info)
+
+	index := make(map[[2]int]int, len(ranges))
+	for i, rng := range ranges {
+		index[trimSpace(src, rng)] = i
+	}
+	file := fset.File(f.Pos())
+	for expr, tv := range info.Types {
+		if fset.File(expr.Pos()) != file {
+			continue
+		}
+		i, ok := index[[2]int{file.Offset(expr.Pos()), file.Offset(expr.End())}]
+		if !ok || tv.Type == nil {
+			continue
+		}
+		t := tv.Type
+		if tuple, isTuple := t.(*types.Tuple); isTuple {
+			if tuple.Len() == 0 {
+				continue
+			}
+			t = tuple.At(0).Type()
+		}
+		if basic, isBasic := t.(*types.Basic); isBasic && basic.Kind() == types.Invalid {
+			continue
+		}
+		results[i] = t
+	}
+	return results
+}
+
+// packageFiles parses the Go files in the package that are included in the build. The code
+// previously generated from the templ file is ignored, since it's being replaced.
+func (r *Resolver) packageFiles(fset *token.FileSet, templFileName, packageName string) (files []*ast.File) {
+	var generated string
+	if abs, err := filepath.Abs(templFileName); err == nil {
+		generated = generatedFileName(abs)
+	}
+	entries, err := os.ReadDir(r.dir)
+	if err != nil {
+		return nil
+	}
+	for _, e := range entries {
+		if e.IsDir() || !isPackageFile(e.Name()) {
+			continue
+		}
+		fileName := filepath.Join(r.dir, e.Name())
+		if fileName == generated {
+			continue
+		}
+		if match, err := build.Default.MatchFile(r.dir, e.Name()); err != nil || !match {
+			continue
+		}
+		f, err := parser.ParseFile(fset, fileName, nil, 0)
+		if err != nil || f.Name.Name != packageName {
+			continue
+		}
+		files = append(files, f)
+	}
+	return files
+}
+
+// exportData finds the compiled export data of the packages imported by the files, and
+// their dependencies, using the go command. The network is never used.
+func (r *Resolver) exportData(files []*ast.File) error {
+	if r.exports == nil {
+		r.exports = make(map[string]string)
+	}
+	var missing []string
+	for _, f := range files {
+		for _, is := range f.Imports {
+			importPath, err := strconv.Unquote(is.Path.Value)
+			if err != nil || importPath == "C" {
+				continue
+			}
+			if _, ok := r.exports[importPath]; !ok && !containsString(missing, importPath) {
+				missing = append(missing, importPath)
+			}
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	sort.Strings(missing)
+	args := append([]string{"list", "-e", "-export", "-deps", "-f", "{{.ImportPath}}\t{{.Export}}", "--"}, missing...)
+	cmd := exec.Command("go", args...)
+	cmd.Dir = r.dir
+	cmd.Env = append(os.Environ(), "GOPROXY=off", "GOFLAGS=-mod=readonly")
+	output, err := cmd.Output()
+	if err != nil {
+		return err
+	}
+	s := bufio.NewScanner(bytes.NewReader(output))
+	for s.Scan() {
+		importPath, fileName, ok := strings.Cut(s.Text(), "\t")
+		if ok {
+			r.exports[importPath] = fileName
+		}
+	}
+	// Don't run the go command again for packages that couldn't be found.
+	for _, importPath := range missing {
+		if _, ok := r.exports[importPath]; !ok {
+			r.exports[importPath] = ""
+		}
+	}
+	return s.Err()
+}
+
+func (r *Resolver) openExportData(importPath string) (io.ReadCloser, error) {
+	fileName := r.exports[importPath]
+	if fileName == "" {
+		return nil, errors.New("no export data for " + importPath)
+	}
+	return os.Open(fileName)
+}
+
+// trimSpace returns the range with leading and trailing whitespace removed.
+func trimSpace(src []byte, rng [2]int) [2]int {
+	from, to := rng[0], rng[1]
+	for from < to && isSpace(src[from]) {
+		from++
+	}
+	for to > from && isSpace(src[to-1]) {
+		to--
+	}
+	return [2]int{from, to}
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
@@ -33,7 +33,11 @@ func personTemplate(p person) templ.Component {
 			return err
 		}
 		// StringExpression
-		var var_2 string = p.name
+		var var_2 string
+		var_2, err = templ.ToString(p.name)
+		if err != nil {
+			return err
+		}
 		_, err = templBuffer.WriteString(templ.EscapeString(var_2))
 		if err != nil {
 			return err
@@ -148,7 +152,11 @@ func email(s string) templ.Component {
 			return err
 		}
 		// StringExpression
-		var var_6 string = s
+		var var_6 string
+		var_6, err = templ.ToString(s)
+		if err != nil {
+			return err
+		}
 		_, err = templBuffer.WriteString(templ.EscapeString(var_6))
 		if err != nil {
 			return err
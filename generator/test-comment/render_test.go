@@ -0,0 +1,27 @@
+package testcomment
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const expected = `<!-- single line HTML comment -->` +
+	`<!--
+	multiline HTML comment
+	-->` +
+	`<p>content<!-- inline --></p>`
+
+func Test(t *testing.T) {
+	component := render("content")
+
+	w := new(strings.Builder)
+	if err := component.Render(context.Background(), w); err != nil {
+		t.Fatalf("failed to render: %v", err)
+	}
+	if diff := cmp.Diff(expected, w.String()); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,67 @@
+// Code generated by templ@(devel) DO NOT EDIT.
+
+package testcomment
+
+//lint:file-ignore SA4006 This context is only used if a nested component is present.
+
+import "github.com/a-h/templ"
+import "context"
+import "io"
+import "bytes"
+
+func render(content string) templ.Component {
+	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
+		templBuffer, templIsBuffer := w.(*bytes.Buffer)
+		if !templIsBuffer {
+			templBuffer = templ.GetBuffer()
+			defer templ.ReleaseBuffer(templBuffer)
+		}
+		ctx = templ.InitializeContext(ctx)
+		var_1 := templ.GetChildren(ctx)
+		if var_1 == nil {
+			var_1 = templ.NopComponent
+		}
+		ctx = templ.ClearChildren(ctx)
+		// Comment
+		_, err = templBuffer.WriteString(`<!-- single line HTML comment -->`)
+		if err != nil {
+			return err
+		}
+		// Comment
+		_, err = templBuffer.WriteString(`<!--
+	multiline HTML comment
+	-->`)
+		if err != nil {
+			return err
+		}
+		// Element (standard)
+		_, err = templBuffer.WriteString("<p>")
+		if err != nil {
+			return err
+		}
+		// StringExpression
+		var var_2 string
+		var_2, err = templ.ToString(content)
+		if err != nil {
+			return err
+		}
+		_, err = templBuffer.WriteString(templ.EscapeString(var_2))
+		if err != nil {
+			return err
+		}
+		// Comment
+		_, err = templBuffer.WriteString(`<!-- inline -->`)
+		if err != nil {
+			return err
+		}
+		_, err = templBuffer.WriteString("</p>")
+		if err != nil {
+			return err
+		}
+		if !templIsBuffer {
+			_, err = io.Copy(w, templBuffer)
+		}
+		return err
+	})
+}
+
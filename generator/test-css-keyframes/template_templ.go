@@ -0,0 +1,150 @@
+// Code generated by templ@(devel) DO NOT EDIT.
+
+package testcsskeyframes
+
+//lint:file-ignore SA4006 This context is only used if a nested component is present.
+
+import "github.com/a-h/templ"
+import "context"
+import "io"
+import "bytes"
+import "strings"
+
+func fadeIn(from string) templ.ComponentCSSAtRule {
+	var templCSSBuilder1 strings.Builder
+	templCSSBuilder1.WriteString(string(templ.SanitizeCSS(`opacity`, from)))
+	var templCSSBuilder2 strings.Builder
+	templCSSBuilder2.WriteString(`opacity:1;`)
+	templCSSID := templ.CSSID(`fadeIn`, `from{` + templCSSBuilder1.String() + `}to{` + templCSSBuilder2.String() + `}`)
+	return templ.ComponentCSSAtRule{
+		Name: templCSSID,
+		Rule: templ.SafeCSS(`@keyframes ` + templCSSID + `{from{` + templCSSBuilder1.String() + `}to{` + templCSSBuilder2.String() + `}}`),
+	}
+}
+
+func brand() templ.ComponentCSSAtRule {
+	var templCSSBuilder strings.Builder
+	templCSSBuilder.WriteString(`src:url("/fonts/brand.woff2") format("woff2");`)
+	templCSSBuilder.WriteString(`font-weight:400;`)
+	templCSSID := templ.CSSID(`brand`, templCSSBuilder.String())
+	return templ.ComponentCSSAtRule{
+		Name: templCSSID,
+		Rule: templ.SafeCSS(`@font-face{font-family:"` + templCSSID + `";` + templCSSBuilder.String() + `}`),
+	}
+}
+
+func banner() templ.CSSClass {
+	var templCSSBuilder strings.Builder
+	templCSSBuilder.WriteString(string(templ.SanitizeCSS(`animation-name`, fadeIn("0").Name)))
+	templCSSBuilder.WriteString(string(templ.SanitizeCSS(`font-family`, brand().Name + ", sans-serif")))
+	templCSSID := templ.CSSID(`banner`, templCSSBuilder.String())
+	return templ.ComponentCSSClass{
+		ID: templCSSID,
+		Class: templ.SafeCSS(`.` + templCSSID + `{` + templCSSBuilder.String() + `}`),
+	}
+}
+
+func Banner() templ.Component {
+	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
+		templBuffer, templIsBuffer := w.(*bytes.Buffer)
+		if !templIsBuffer {
+			templBuffer = templ.GetBuffer()
+			defer templ.ReleaseBuffer(templBuffer)
+		}
+		ctx = templ.InitializeContext(ctx)
+		var_1 := templ.GetChildren(ctx)
+		if var_1 == nil {
+			var_1 = templ.NopComponent
+		}
+		ctx = templ.ClearChildren(ctx)
+		// Element (standard)
+		// Element CSS
+		var var_2 = []any{fadeIn("0"), brand(), banner()}
+		err = templ.RenderCSSItems(ctx, templBuffer, var_2...)
+		if err != nil {
+			return err
+		}
+		_, err = templBuffer.WriteString("<div")
+		if err != nil {
+			return err
+		}
+		// Element Attributes
+		_, err = templBuffer.WriteString(" class=")
+		if err != nil {
+			return err
+		}
+		_, err = templBuffer.WriteString("\"")
+		if err != nil {
+			return err
+		}
+		_, err = templBuffer.WriteString(templ.EscapeString(templ.CSSClasses(var_2).String()))
+		if err != nil {
+			return err
+		}
+		_, err = templBuffer.WriteString("\"")
+		if err != nil {
+			return err
+		}
+		_, err = templBuffer.WriteString(">")
+		if err != nil {
+			return err
+		}
+		// Text
+		var_3 := `Hello`
+		_, err = templBuffer.WriteString(var_3)
+		if err != nil {
+			return err
+		}
+		_, err = templBuffer.WriteString("</div>")
+		if err != nil {
+			return err
+		}
+		// Element (standard)
+		// Element CSS
+		var var_4 = []any{fadeIn("0"), banner()}
+		err = templ.RenderCSSItems(ctx, templBuffer, var_4...)
+		if err != nil {
+			return err
+		}
+		_, err = templBuffer.WriteString("<div")
+		if err != nil {
+			return err
+		}
+		// Element Attributes
+		_, err = templBuffer.WriteString(" class=")
+		if err != nil {
+			return err
+		}
+		_, err = templBuffer.WriteString("\"")
+		if err != nil {
+			return err
+		}
+		_, err = templBuffer.WriteString(templ.EscapeString(templ.CSSClasses(var_4).String()))
+		if err != nil {
+			return err
+		}
+		_, err = templBuffer.WriteString("\"")
+		if err != nil {
+			return err
+		}
+		_, err = templBuffer.WriteString(">")
+		if err != nil {
+			return err
+		}
+		// Text
+		var_5 := `Again`
+		_, err = templBuffer.WriteString(var_5)
+		if err != nil {
+			return err
+		}
+		_, err = templBuffer.WriteString("</div>")
+		if err != nil {
+			return err
+		}
+		if !templIsBuffer {
+			_, err = io.Copy(w, templBuffer)
+		}
+		return err
+	})
+}
+
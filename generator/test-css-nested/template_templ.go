@@ -0,0 +1,123 @@
+// Code generated by templ@(devel) DO NOT EDIT.
+
+package testcssnested
+
+//lint:file-ignore SA4006 This context is only used if a nested component is present.
+
+import "github.com/a-h/templ"
+import "context"
+import "io"
+import "bytes"
+import "strings"
+
+func menu(highlight string) templ.CSSClass {
+	var templCSSBuilder strings.Builder
+	templCSSBuilder.WriteString(`padding:4px;`)
+	var templCSSBuilder1 strings.Builder
+	templCSSBuilder1.WriteString(string(templ.SanitizeCSS(`color`, highlight)))
+	var templCSSBuilder2 strings.Builder
+	templCSSBuilder2.WriteString(`list-style:none;`)
+	var templCSSBuilder3 strings.Builder
+	templCSSBuilder3.WriteString(`color:#ff0000;`)
+	var templCSSBuilder4 strings.Builder
+	templCSSBuilder4.WriteString(`color:#888888;`)
+	var templCSSBuilder5 strings.Builder
+	templCSSBuilder5.WriteString(`padding:0;`)
+	var templCSSBuilder6 strings.Builder
+	templCSSBuilder6.WriteString(`display:block;`)
+	templCSSID := templ.CSSID(`menu`, templCSSBuilder.String() + `:hover{` + templCSSBuilder1.String() + `} > li, > .item{` + templCSSBuilder2.String() + `} > li a:focus, > li.active a, > .item a:focus, > .item.active a{` + templCSSBuilder3.String() + `}::marker{` + templCSSBuilder4.String() + `}@media (max-width: 600px){{` + templCSSBuilder5.String() + `} li{` + templCSSBuilder6.String() + `}}`)
+	return templ.ComponentCSSClass{
+		ID: templCSSID,
+		Class: templ.SafeCSS(`.` + templCSSID + `{` + templCSSBuilder.String() + `}` + `.` + templCSSID + `:hover{` + templCSSBuilder1.String() + `}.` + templCSSID + ` > li,.` + templCSSID + ` > .item{` + templCSSBuilder2.String() + `}.` + templCSSID + ` > li a:focus,.` + templCSSID + ` > li.active a,.` + templCSSID + ` > .item a:focus,.` + templCSSID + ` > .item.active a{` + templCSSBuilder3.String() + `}.` + templCSSID + `::marker{` + templCSSBuilder4.String() + `}@media (max-width: 600px){.` + templCSSID + `{` + templCSSBuilder5.String() + `}.` + templCSSID + ` li{` + templCSSBuilder6.String() + `}}`),
+	}
+}
+
+func Menu() templ.Component {
+	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
+		templBuffer, templIsBuffer := w.(*bytes.Buffer)
+		if !templIsBuffer {
+			templBuffer = templ.GetBuffer()
+			defer templ.ReleaseBuffer(templBuffer)
+		}
+		ctx = templ.InitializeContext(ctx)
+		var_1 := templ.GetChildren(ctx)
+		if var_1 == nil {
+			var_1 = templ.NopComponent
+		}
+		ctx = templ.ClearChildren(ctx)
+		// Element (standard)
+		// Element CSS
+		var var_2 = []any{menu("#0000ff")}
+		err = templ.RenderCSSItems(ctx, templBuffer, var_2...)
+		if err != nil {
+			return err
+		}
+		_, err = templBuffer.WriteString("<ul")
+		if err != nil {
+			return err
+		}
+		// Element Attributes
+		_, err = templBuffer.WriteString(" class=")
+		if err != nil {
+			return err
+		}
+		_, err = templBuffer.WriteString("\"")
+		if err != nil {
+			return err
+		}
+		_, err = templBuffer.WriteString(templ.EscapeString(templ.CSSClasses(var_2).String()))
+		if err != nil {
+			return err
+		}
+		_, err = templBuffer.WriteString("\"")
+		if err != nil {
+			return err
+		}
+		_, err = templBuffer.WriteString(">")
+		if err != nil {
+			return err
+		}
+		// Element (standard)
+		_, err = templBuffer.WriteString("<li>")
+		if err != nil {
+			return err
+		}
+		// Element (standard)
+		_, err = templBuffer.WriteString("<a")
+		if err != nil {
+			return err
+		}
+		// Element Attributes
+		_, err = templBuffer.WriteString(" href=\"/\"")
+		if err != nil {
+			return err
+		}
+		_, err = templBuffer.WriteString(">")
+		if err != nil {
+			return err
+		}
+		// Text
+		var_3 := `Home`
+		_, err = templBuffer.WriteString(var_3)
+		if err != nil {
+			return err
+		}
+		_, err = templBuffer.WriteString("</a>")
+		if err != nil {
+			return err
+		}
+		_, err = templBuffer.WriteString("</li>")
+		if err != nil {
+			return err
+		}
+		_, err = templBuffer.WriteString("</ul>")
+		if err != nil {
+			return err
+		}
+		if !templIsBuffer {
+			_, err = io.Copy(w, templBuffer)
+		}
+		return err
+	})
+}
+
@@ -81,7 +81,11 @@ func Button(text string) templ.Component {
 			return err
 		}
 		// StringExpression
-		var var_3 string = text
+		var var_3 string
+		var_3, err = templ.ToString(text)
+		if err != nil {
+			return err
+		}
 		_, err = templBuffer.WriteString(templ.EscapeString(var_3))
 		if err != nil {
 			return err
@@ -325,7 +329,11 @@ func ThreeButtons() templ.Component {
 			return err
 		}
 		// StringExpression
-		var var_12 string = "Green"
+		var var_12 string
+		var_12, err = templ.ToString("Green")
+		if err != nil {
+			return err
+		}
 		_, err = templBuffer.WriteString(templ.EscapeString(var_12))
 		if err != nil {
 			return err
@@ -102,7 +102,11 @@ func Layout(title, content string) templ.Component {
 			return err
 		}
 		// StringExpression
-		var var_2 string = title
+		var var_2 string
+		var_2, err = templ.ToString(title)
+		if err != nil {
+			return err
+		}
 		_, err = templBuffer.WriteString(templ.EscapeString(var_2))
 		if err != nil {
 			return err
@@ -121,7 +125,11 @@ func Layout(title, content string) templ.Component {
 			return err
 		}
 		// StringExpression
-		var var_3 string = content
+		var var_3 string
+		var_3, err = templ.ToString(content)
+		if err != nil {
+			return err
+		}
 		_, err = templBuffer.WriteString(templ.EscapeString(var_3))
 		if err != nil {
 			return err
@@ -0,0 +1,113 @@
+// Code generated by templ@(devel) DO NOT EDIT.
+
+package elseif
+
+//lint:file-ignore SA4006 This context is only used if a nested component is present.
+
+import "github.com/a-h/templ"
+import "context"
+import "io"
+import "bytes"
+
+func render(items []int) templ.Component {
+	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
+		templBuffer, templIsBuffer := w.(*bytes.Buffer)
+		if !templIsBuffer {
+			templBuffer = templ.GetBuffer()
+			defer templ.ReleaseBuffer(templBuffer)
+		}
+		ctx = templ.InitializeContext(ctx)
+		var_1 := templ.GetChildren(ctx)
+		if var_1 == nil {
+			var_1 = templ.NopComponent
+		}
+		ctx = templ.ClearChildren(ctx)
+		// For
+		for _, i := range items {
+			// Element (standard)
+			_, err = templBuffer.WriteString("<div")
+			if err != nil {
+				return err
+			}
+			// Element Attributes
+			if i == 1 {
+				// Element Attributes
+				_, err = templBuffer.WriteString(" class=\"one\"")
+				if err != nil {
+					return err
+				}
+			} else if i == 2 {
+				// Element Attributes
+				_, err = templBuffer.WriteString(" class=\"two\"")
+				if err != nil {
+					return err
+				}
+			} else {
+				// Element Attributes
+				_, err = templBuffer.WriteString(" class=\"other\"")
+				if err != nil {
+					return err
+				}
+			}
+			_, err = templBuffer.WriteString(">")
+			if err != nil {
+				return err
+			}
+			// If
+			if i == 1 {
+				// StringExpression
+				var var_2 string
+				var_2, err = templ.ToString("One")
+				if err != nil {
+					return err
+				}
+				_, err = templBuffer.WriteString(templ.EscapeString(var_2))
+				if err != nil {
+					return err
+				}
+			} else if i == 2 {
+				// StringExpression
+				var var_3 string
+				var_3, err = templ.ToString("Two")
+				if err != nil {
+					return err
+				}
+				_, err = templBuffer.WriteString(templ.EscapeString(var_3))
+				if err != nil {
+					return err
+				}
+			} else if i == 3 {
+				// StringExpression
+				var var_4 string
+				var_4, err = templ.ToString("Three")
+				if err != nil {
+					return err
+				}
+				_, err = templBuffer.WriteString(templ.EscapeString(var_4))
+				if err != nil {
+					return err
+				}
+			} else {
+				// StringExpression
+				var var_5 string
+				var_5, err = templ.ToString("Other")
+				if err != nil {
+					return err
+				}
+				_, err = templBuffer.WriteString(templ.EscapeString(var_5))
+				if err != nil {
+					return err
+				}
+			}
+			_, err = templBuffer.WriteString("</div>")
+			if err != nil {
+				return err
+			}
+		}
+		if !templIsBuffer {
+			_, err = io.Copy(w, templBuffer)
+		}
+		return err
+	})
+}
+
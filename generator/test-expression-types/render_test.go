@@ -0,0 +1,57 @@
+package testexpressiontypes
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/a-h/templ/generator/htmldiff"
+)
+
+//go:embed expected.html
+var expected string
+
+func Test(t *testing.T) {
+	component := Profile(User{Name: "Ann", Age: 42, Status: "active"}, 90*time.Second, 21.5)
+
+	diff, err := htmldiff.Diff(component, expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestStringers(t *testing.T) {
+	joinedAt := time.Date(2023, time.January, 2, 3, 4, 5, 0, time.UTC)
+	component := Details(joinedAt, &Address{Street: "1 High Street", City: "London"})
+
+	diff, err := htmldiff.Diff(component, `<div>2023-01-02 03:04:05 +0000 UTC</div><div>2023-01-02 03:04:05 +0000 UTC</div><div>1 High Street, London</div>`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestNilStringersReturnAnError(t *testing.T) {
+	err := Details(time.Time{}, nil).Render(context.Background(), new(bytes.Buffer))
+	if err == nil {
+		t.Error("expected an error, got nil")
+	}
+}
+
+func TestErrorsAreReturned(t *testing.T) {
+	expectedErr := errors.New("failed")
+	component := Failing(func() (int, error) { return 0, expectedErr })
+
+	err := component.Render(context.Background(), new(bytes.Buffer))
+	if !errors.Is(err, expectedErr) {
+		t.Errorf("expected %v, got %v", expectedErr, err)
+	}
+}
@@ -0,0 +1,174 @@
+// Code generated by templ@(devel) DO NOT EDIT.
+
+package testgocode
+
+//lint:file-ignore SA4006 This context is only used if a nested component is present.
+
+import "github.com/a-h/templ"
+import "context"
+import "io"
+import "bytes"
+
+// GoExpression
+import "strconv"
+
+func Invoice(items []int) templ.Component {
+	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
+		templBuffer, templIsBuffer := w.(*bytes.Buffer)
+		if !templIsBuffer {
+			templBuffer = templ.GetBuffer()
+			defer templ.ReleaseBuffer(templBuffer)
+		}
+		ctx = templ.InitializeContext(ctx)
+		var_1 := templ.GetChildren(ctx)
+		if var_1 == nil {
+			var_1 = templ.NopComponent
+		}
+		ctx = templ.ClearChildren(ctx)
+		// GoCode
+		total := 0
+		// Element (standard)
+		_, err = templBuffer.WriteString("<ul>")
+		if err != nil {
+			return err
+		}
+		// For
+		for i, item := range items {
+			// GoCode
+			total += item
+				label := "item " + strconv.Itoa(i+1)
+			// Element (standard)
+			_, err = templBuffer.WriteString("<li>")
+			if err != nil {
+				return err
+			}
+			// StringExpression
+			var var_2 string
+			var_2, err = templ.ToString(label)
+			if err != nil {
+				return err
+			}
+			_, err = templBuffer.WriteString(templ.EscapeString(var_2))
+			if err != nil {
+				return err
+			}
+			// Text
+			var_3 := `: `
+			_, err = templBuffer.WriteString(var_3)
+			if err != nil {
+				return err
+			}
+			// StringExpression
+			var var_4 string
+			var_4, err = templ.ToString(strconv.Itoa(item))
+			if err != nil {
+				return err
+			}
+			_, err = templBuffer.WriteString(templ.EscapeString(var_4))
+			if err != nil {
+				return err
+			}
+			_, err = templBuffer.WriteString("</li>")
+			if err != nil {
+				return err
+			}
+		}
+		_, err = templBuffer.WriteString("</ul>")
+		if err != nil {
+			return err
+		}
+		// If
+		if total > 10 {
+			// GoCode
+			label := "large"
+			// Element (standard)
+			_, err = templBuffer.WriteString("<p>")
+			if err != nil {
+				return err
+			}
+			// StringExpression
+			var var_5 string
+			var_5, err = templ.ToString(label)
+			if err != nil {
+				return err
+			}
+			_, err = templBuffer.WriteString(templ.EscapeString(var_5))
+			if err != nil {
+				return err
+			}
+			// Whitespace (normalised)
+			_, err = templBuffer.WriteString(` `)
+			if err != nil {
+				return err
+			}
+			// Text
+			var_6 := `total: `
+			_, err = templBuffer.WriteString(var_6)
+			if err != nil {
+				return err
+			}
+			// StringExpression
+			var var_7 string
+			var_7, err = templ.ToString(strconv.Itoa(total))
+			if err != nil {
+				return err
+			}
+			_, err = templBuffer.WriteString(templ.EscapeString(var_7))
+			if err != nil {
+				return err
+			}
+			_, err = templBuffer.WriteString("</p>")
+			if err != nil {
+				return err
+			}
+		} else {
+			// GoCode
+			label := "small"
+			// Element (standard)
+			_, err = templBuffer.WriteString("<p>")
+			if err != nil {
+				return err
+			}
+			// StringExpression
+			var var_8 string
+			var_8, err = templ.ToString(label)
+			if err != nil {
+				return err
+			}
+			_, err = templBuffer.WriteString(templ.EscapeString(var_8))
+			if err != nil {
+				return err
+			}
+			// Whitespace (normalised)
+			_, err = templBuffer.WriteString(` `)
+			if err != nil {
+				return err
+			}
+			// Text
+			var_9 := `total: `
+			_, err = templBuffer.WriteString(var_9)
+			if err != nil {
+				return err
+			}
+			// StringExpression
+			var var_10 string
+			var_10, err = templ.ToString(strconv.Itoa(total))
+			if err != nil {
+				return err
+			}
+			_, err = templBuffer.WriteString(templ.EscapeString(var_10))
+			if err != nil {
+				return err
+			}
+			_, err = templBuffer.WriteString("</p>")
+			if err != nil {
+				return err
+			}
+		}
+		if !templIsBuffer {
+			_, err = io.Copy(w, templBuffer)
+		}
+		return err
+	})
+}
+
@@ -33,7 +33,11 @@ func render(p person) templ.Component {
 			return err
 		}
 		// StringExpression
-		var var_2 string = p.name
+		var var_2 string
+		var_2, err = templ.ToString(p.name)
+		if err != nil {
+			return err
+		}
 		_, err = templBuffer.WriteString(templ.EscapeString(var_2))
 		if err != nil {
 			return err
@@ -115,7 +119,11 @@ func render(p person) templ.Component {
 			return err
 		}
 		// StringExpression
-		var var_5 string = p.email
+		var var_5 string
+		var_5, err = templ.ToString(p.email)
+		if err != nil {
+			return err
+		}
 		_, err = templBuffer.WriteString(templ.EscapeString(var_5))
 		if err != nil {
 			return err
@@ -25,14 +25,22 @@ func render(d data) templ.Component {
 		// If
 		if d.IsTrue() {
 			// StringExpression
-			var var_2 string = "True"
+			var var_2 string
+			var_2, err = templ.ToString("True")
+			if err != nil {
+				return err
+			}
 			_, err = templBuffer.WriteString(templ.EscapeString(var_2))
 			if err != nil {
 				return err
 			}
 		} else {
 			// StringExpression
-			var var_3 string = "False"
+			var var_3 string
+			var_3, err = templ.ToString("False")
+			if err != nil {
+				return err
+			}
 			_, err = templBuffer.WriteString(templ.EscapeString(var_3))
 			if err != nil {
 				return err
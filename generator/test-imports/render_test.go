@@ -0,0 +1,21 @@
+package testimports
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRender(t *testing.T) {
+	w := new(strings.Builder)
+	err := render("héllo").Render(context.Background(), w)
+	if err != nil {
+		t.Errorf("failed to render: %v", err)
+	}
+	expected := `<div>HÉLLO</div><div>5</div>`
+	if diff := cmp.Diff(expected, w.String()); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -92,7 +92,11 @@ func Button(text string) templ.Component {
 			return err
 		}
 		// StringExpression
-		var var_4 string = text
+		var var_4 string
+		var_4, err = templ.ToString(text)
+		if err != nil {
+			return err
+		}
 		_, err = templBuffer.WriteString(templ.EscapeString(var_4))
 		if err != nil {
 			return err
@@ -23,7 +23,11 @@ func render(s string) templ.Component {
 		}
 		ctx = templ.ClearChildren(ctx)
 		// StringExpression
-		var var_2 string = s
+		var var_2 string
+		var_2, err = templ.ToString(s)
+		if err != nil {
+			return err
+		}
 		_, err = templBuffer.WriteString(templ.EscapeString(var_2))
 		if err != nil {
 			return err
@@ -25,13 +25,21 @@ func render(input string) templ.Component {
 		// Switch
 		switch input {
 		case "a":			// StringExpression
-			var var_2 string = "it was 'a'"
+			var var_2 string
+			var_2, err = templ.ToString("it was 'a'")
+			if err != nil {
+				return err
+			}
 			_, err = templBuffer.WriteString(templ.EscapeString(var_2))
 			if err != nil {
 				return err
 			}
 		default:			// StringExpression
-			var var_3 string = "it was something else"
+			var var_3 string
+			var_3, err = templ.ToString("it was something else")
+			if err != nil {
+				return err
+			}
 			_, err = templBuffer.WriteString(templ.EscapeString(var_3))
 			if err != nil {
 				return err
@@ -210,7 +210,11 @@ func WhiteSpaceAroundValues() templ.Component {
 			return err
 		}
 		// StringExpression
-		var var_14 string = "strings"
+		var var_14 string
+		var_14, err = templ.ToString("strings")
+		if err != nil {
+			return err
+		}
 		_, err = templBuffer.WriteString(templ.EscapeString(var_14))
 		if err != nil {
 			return err
@@ -34,7 +34,11 @@ func BasicTemplate(name string) templ.Component {
 			return err
 		}
 		// StringExpression
-		var var_3 string = name
+		var var_3 string
+		var_3, err = templ.ToString(name)
+		if err != nil {
+			return err
+		}
 		_, err = templBuffer.WriteString(templ.EscapeString(var_3))
 		if err != nil {
 			return err
@@ -85,7 +89,11 @@ func BasicTemplate(name string) templ.Component {
 			return err
 		}
 		// StringExpression
-		var var_7 string = name
+		var var_7 string
+		var_7, err = templ.ToString(name)
+		if err != nil {
+			return err
+		}
 		_, err = templBuffer.WriteString(templ.EscapeString(var_7))
 		if err != nil {
 			return err
@@ -9,8 +9,10 @@ require (
 	github.com/a-h/parse v0.0.0-20230402144745-e6c8bc86e846
 	github.com/a-h/pathvars v0.0.12
 	github.com/a-h/protocol v0.0.0-20230224160810-b4eec67c1c22
+	github.com/fsnotify/fsnotify v1.6.0
 	github.com/google/go-cmp v0.5.9
 	github.com/natefinch/atomic v1.0.1
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/rs/cors v1.8.3
 	go.lsp.dev/jsonrpc2 v0.10.0
 	go.lsp.dev/uri v0.3.0
@@ -0,0 +1,197 @@
+package parser
+
+import "fmt"
+
+// An ApplyFunc is invoked by Apply for each node, before and/or after the node's
+// children, using a Cursor describing the current node and providing operations
+// on it.
+//
+// The return value of ApplyFunc controls the syntax tree traversal.
+// See Apply for details.
+type ApplyFunc func(*Cursor) bool
+
+// Apply traverses a template file recursively, starting with root, and calling
+// pre and post for each node as described below. Apply returns the template file,
+// possibly modified. The root must be a value of one of the types that Walk visits.
+//
+// If pre is not nil, it is called for each node before the node's children are
+// traversed (pre-order). If pre returns false, no children are traversed, and
+// post is not called for that node.
+//
+// If post is not nil, and a prior call of pre didn't return false, post is called
+// for each node after its children are traversed (post-order). If post returns
+// false, traversal is terminated and Apply returns immediately.
+//
+// Nodes are values, so changes are made by replacing nodes with the Cursor, rather
+// than by changing them in place. Nodes that aren't replaced keep their positions.
+func Apply(root any, pre, post ApplyFunc) (result any) {
+	a := &application{pre: pre, post: post}
+	return applyField(a, nil, root)
+}
+
+// A Cursor describes a node encountered during Apply. Information about the node
+// and its parent is available from the Node and Parent methods.
+type Cursor struct {
+	parent  any
+	node    any
+	inList  bool
+	deleted bool
+	before  []any
+	after   []any
+}
+
+// Node returns the current node.
+func (c *Cursor) Node() any { return c.node }
+
+// Parent returns the parent of the current node, as it was before its children
+// were traversed. The parent of the root is nil.
+func (c *Cursor) Parent() any { return c.parent }
+
+// Replace replaces the current node with n. The replacement node is not walked
+// by Apply. It must have the same type as the field or list that holds the node,
+// e.g. a Node for the children of an Element.
+func (c *Cursor) Replace(n any) {
+	c.node = n
+}
+
+// Delete deletes the current node from its containing list.
+// If the current node is not part of a list, Delete panics.
+func (c *Cursor) Delete() {
+	if !c.inList {
+		panic(fmt.Sprintf("parser: Delete of %T node not contained in a list", c.node))
+	}
+	c.deleted = true
+}
+
+// InsertBefore inserts n before the current node in its containing list.
+// If the current node is not part of a list, InsertBefore panics.
+// Apply does not walk n.
+func (c *Cursor) InsertBefore(n any) {
+	if !c.inList {
+		panic(fmt.Sprintf("parser: InsertBefore of %T node not contained in a list", c.node))
+	}
+	c.before = append(c.before, n)
+}
+
+// InsertAfter inserts n after the current node in its containing list.
+// If the current node is not part of a list, InsertAfter panics.
+// Apply does not walk n.
+func (c *Cursor) InsertAfter(n any) {
+	if !c.inList {
+		panic(fmt.Sprintf("parser: InsertAfter of %T node not contained in a list", c.node))
+	}
+	c.after = append(c.after, n)
+}
+
+type application struct {
+	pre, post ApplyFunc
+	aborted   bool
+}
+
+// visit calls pre and post for the node at the cursor, and applies them to the
+// node's children.
+func (a *application) visit(c *Cursor) {
+	if a.pre != nil && !a.pre(c) {
+		return
+	}
+	if c.deleted {
+		return
+	}
+	c.node = a.children(c.node)
+	if a.post != nil && !a.post(c) {
+		a.aborted = true
+	}
+}
+
+func applyField[T any](a *application, parent any, n T) T {
+	if a.aborted {
+		return n
+	}
+	c := &Cursor{parent: parent, node: n}
+	a.visit(c)
+	return c.node.(T)
+}
+
+func applyList[T any](a *application, parent any, list []T) (op []T) {
+	if len(list) == 0 {
+		return list
+	}
+	op = make([]T, 0, len(list))
+	for i, n := range list {
+		if a.aborted {
+			return append(op, list[i:]...)
+		}
+		c := &Cursor{parent: parent, node: n, inList: true}
+		a.visit(c)
+		for _, before := range c.before {
+			op = append(op, before.(T))
+		}
+		if !c.deleted {
+			op = append(op, c.node.(T))
+		}
+		for _, after := range c.after {
+			op = append(op, after.(T))
+		}
+	}
+	return op
+}
+
+func (a *application) children(node any) any {
+	switch n := node.(type) {
+	case TemplateFile:
+		n.Package = applyField(a, node, n.Package)
+		n.Nodes = applyList(a, node, n.Nodes)
+		return n
+	case HTMLTemplate:
+		n.Children = applyList(a, node, n.Children)
+		return n
+	case CSSTemplate:
+		n.Properties = applyList(a, node, n.Properties)
+		return n
+	case ExpressionCSSProperty:
+		n.Value = applyField(a, node, n.Value)
+		return n
+	case CSSRule:
+		n.Properties = applyList(a, node, n.Properties)
+		return n
+	case Element:
+		n.Attributes = applyList(a, node, n.Attributes)
+		n.Children = applyList(a, node, n.Children)
+		return n
+	case RawElement:
+		n.Attributes = applyList(a, node, n.Attributes)
+		return n
+	case ConditionalAttribute:
+		n.Then = applyList(a, node, n.Then)
+		n.ElseIfs = applyList(a, node, n.ElseIfs)
+		n.Else = applyList(a, node, n.Else)
+		return n
+	case ConditionalAttributeElseIf:
+		n.Then = applyList(a, node, n.Then)
+		return n
+	case InterpolatedAttribute:
+		n.Parts = applyList(a, node, n.Parts)
+		return n
+	case TemplElementExpression:
+		n.Children = applyList(a, node, n.Children)
+		return n
+	case IfExpression:
+		n.Then = applyList(a, node, n.Then)
+		n.ElseIfs = applyList(a, node, n.ElseIfs)
+		n.Else = applyList(a, node, n.Else)
+		return n
+	case ElseIfExpression:
+		n.Then = applyList(a, node, n.Then)
+		return n
+	case SwitchExpression:
+		n.Cases = applyList(a, node, n.Cases)
+		return n
+	case CaseExpression:
+		n.Children = applyList(a, node, n.Children)
+		return n
+	case ForExpression:
+		n.Children = applyList(a, node, n.Children)
+		return n
+	}
+	return node
+}
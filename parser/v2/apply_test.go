@@ -0,0 +1,122 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestApply(t *testing.T) {
+	tf, err := ParseString(walkTemplate)
+	if err != nil {
+		t.Fatalf("failed to parse template: %v", err)
+	}
+	var divRange Range
+	result := Apply(tf, func(c *Cursor) bool {
+		switch n := c.Node().(type) {
+		case Comment:
+			c.Delete()
+		case BoolConstantAttribute:
+			if n.Name == "hidden" {
+				c.InsertAfter(ConstantAttribute{Name: "aria-hidden", Value: "true"})
+			}
+		case Element:
+			if n.Name == "b" {
+				n.Name = "strong"
+				c.Replace(n)
+			}
+		case TemplElementExpression:
+			c.InsertBefore(Text{Value: "Item:"})
+		case InterpolatedAttributePart:
+			if n.IsExpression() {
+				n.Expression.Value = "strings.ToUpper(" + n.Expression.Value + ")"
+				c.Replace(n)
+			}
+		}
+		return true
+	}, func(c *Cursor) bool {
+		if e, isElement := c.Node().(Element); isElement && e.Name == "div" {
+			divRange = e.Range
+		}
+		return true
+	}).(TemplateFile)
+
+	w := new(strings.Builder)
+	if err := result.Write(w); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+	expected := `package main
+
+templ Name(items []string) {
+	<div class="a" title="Hello, { strings.ToUpper(name) }"
+		if ok {
+			disabled
+		} else if other {
+			autofocus
+		} else {
+			hidden
+			aria-hidden="true"
+		}
+		>
+		if ok {
+			<span>{ "a" }</span>
+		} else if other {
+			<hr/>
+		} else {
+			<br/>
+		}
+		for _, item := range items {
+			Item:
+			@Item(item)
+		}
+		switch x {
+			case 1:
+				<strong>1</strong>
+		}
+	</div>
+}
+
+css Style() {
+	color: { red };
+	&:hover {
+		color: { blue };
+	}
+}
+
+`
+	if diff := cmp.Diff(expected, w.String()); diff != "" {
+		t.Error(diff)
+	}
+	if divRange.From.Line != 3 || divRange.To.Line != 25 {
+		t.Errorf("expected the div to keep its position, got %v", divRange)
+	}
+	if _, hasComment := tf.Nodes[0].(HTMLTemplate).Children[1].(Element).Children[1].(Comment); !hasComment {
+		t.Error("expected the original template file to be unchanged")
+	}
+}
+
+func TestApplyStopsWhenPostReturnsFalse(t *testing.T) {
+	tf, err := ParseString(walkTemplate)
+	if err != nil {
+		t.Fatalf("failed to parse template: %v", err)
+	}
+	var visited int
+	Apply(tf, nil, func(c *Cursor) bool {
+		visited++
+		_, isText := c.Node().(Text)
+		return !isText
+	})
+	var expected int
+	Inspect(tf, func(n any) bool {
+		if n == nil {
+			return false
+		}
+		expected++
+		_, isText := n.(Text)
+		return !isText
+	})
+	if visited >= expected {
+		t.Errorf("expected traversal to stop at the first text node, but visited %d of %d nodes", visited, expected)
+	}
+}
@@ -11,6 +11,8 @@ var callTemplateExpressionStart = parse.Or(parse.String("{! "), parse.String("{!
 type callTemplateExpressionParser struct{}
 
 func (p callTemplateExpressionParser) Parse(pi *parse.Input) (r CallTemplateExpression, ok bool, err error) {
+	from := pi.Position()
+
 	// Check the prefix first.
 	if _, ok, err = callTemplateExpressionStart.Parse(pi); err != nil || !ok {
 		return
@@ -20,11 +22,15 @@ func (p callTemplateExpressionParser) Parse(pi *parse.Input) (r CallTemplateExpr
 	if r.Expression, ok, err = exp.Parse(pi); err != nil || !ok {
 		return
 	}
+	if err = validateGoExpression("call template expression", r.Expression); err != nil {
+		return r, false, err
+	}
 
 	// Eat the final brace.
 	if _, ok, err = Must(closeBraceWithOptionalPadding, "call template expression: missing closing brace").Parse(pi); err != nil || !ok {
 		return
 	}
+	r.Range = NewRange(from, pi.Position())
 
 	return r, true, nil
 }
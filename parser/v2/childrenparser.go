@@ -5,12 +5,16 @@ import (
 )
 
 var childrenExpression = parse.Func(func(in *parse.Input) (out ChildrenExpression, ok bool, err error) {
-	_, ok, err = parse.StringFrom(
+	from := in.Position()
+	if _, ok, err = parse.StringFrom(
 		openBraceWithOptionalPadding,
 		parse.OptionalWhitespace,
 		parse.String("children..."),
 		parse.OptionalWhitespace,
 		closeBraceWithOptionalPadding,
-	).Parse(in)
-	return out, ok, err
+	).Parse(in); err != nil || !ok {
+		return
+	}
+	out.Range = NewRange(from, in.Position())
+	return out, true, nil
 })
@@ -0,0 +1,89 @@
+package parser
+
+import (
+	"github.com/a-h/parse"
+)
+
+var htmlCommentStart = parse.String("<!--")
+var htmlCommentEnd = parse.String("-->")
+
+var htmlCommentParser = parse.Func(func(pi *parse.Input) (c Comment, ok bool, err error) {
+	from := pi.Position()
+	if _, ok, err = htmlCommentStart.Parse(pi); err != nil || !ok {
+		return
+	}
+	c.Type = CommentTypeHTML
+	if c.Contents, ok, err = parse.StringUntil(htmlCommentEnd).Parse(pi); err != nil {
+		return
+	}
+	if !ok {
+		err = parse.Error("html comment: unterminated (missing '-->')", from)
+		return
+	}
+	if _, ok, err = htmlCommentEnd.Parse(pi); err != nil || !ok {
+		return
+	}
+	c.Range = NewRange(from, pi.Position())
+	return c, true, nil
+})
+
+var singleLineCommentStart = parse.String("//")
+var multilineCommentStart = parse.String("/*")
+var multilineCommentEnd = parse.String("*/")
+
+// templCommentParser parses // and /* */ comments. To avoid treating text such as URLs as
+// comments, they must be at the start of a line.
+var templCommentParser = parse.Func(func(pi *parse.Input) (c Comment, ok bool, err error) {
+	if !atLineStart(pi) {
+		return
+	}
+	from := pi.Position()
+	if _, ok, err = singleLineCommentStart.Parse(pi); err != nil {
+		return
+	}
+	if ok {
+		c.Type = CommentTypeSingleLine
+		if c.Contents, ok, err = parse.StringUntilEOF(parse.NewLine).Parse(pi); err != nil || !ok {
+			return
+		}
+		c.Range = NewRange(from, pi.Position())
+		return c, true, nil
+	}
+	if _, ok, err = multilineCommentStart.Parse(pi); err != nil || !ok {
+		return
+	}
+	c.Type = CommentTypeMultiline
+	if c.Contents, ok, err = parse.StringUntil(multilineCommentEnd).Parse(pi); err != nil {
+		return
+	}
+	if !ok {
+		err = parse.Error("comment: unterminated (missing '*/')", from)
+		return
+	}
+	if _, ok, err = multilineCommentEnd.Parse(pi); err != nil || !ok {
+		return
+	}
+	c.Range = NewRange(from, pi.Position())
+	return c, true, nil
+})
+
+var commentParser = parse.Any[Comment](htmlCommentParser, templCommentParser)
+
+// atLineStart returns true if there's only whitespace between the start of the line and
+// the current position.
+func atLineStart(pi *parse.Input) bool {
+	start := pi.Index()
+	defer pi.Seek(start)
+	for i := start - 1; i >= 0; i-- {
+		pi.Seek(i)
+		s, _ := pi.Peek(1)
+		switch s {
+		case "\n":
+			return true
+		case " ", "\t", "\r":
+			continue
+		}
+		return false
+	}
+	return true
+}
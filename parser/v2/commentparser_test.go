@@ -0,0 +1,139 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/a-h/parse"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCommentParser(t *testing.T) {
+	var tests = []struct {
+		name     string
+		input    string
+		expected Comment
+	}{
+		{
+			name:  "html comment",
+			input: `<!-- simple -->`,
+			expected: Comment{
+				Contents: " simple ",
+				Type:     CommentTypeHTML,
+			},
+		},
+		{
+			name: "html comment over multiple lines",
+			input: `<!--
+	line 1
+	line 2
+-->`,
+			expected: Comment{
+				Contents: "\n\tline 1\n\tline 2\n",
+				Type:     CommentTypeHTML,
+			},
+		},
+		{
+			name:  "html comment containing tags",
+			input: `<!-- <div>{ "x" }</div> -->`,
+			expected: Comment{
+				Contents: ` <div>{ "x" }</div> `,
+				Type:     CommentTypeHTML,
+			},
+		},
+		{
+			name:  "single line comment runs to the end of the line",
+			input: "// comment <div>\n<div></div>",
+			expected: Comment{
+				Contents: " comment <div>",
+				Type:     CommentTypeSingleLine,
+			},
+		},
+		{
+			name:  "single line comment at the end of the file",
+			input: "// comment",
+			expected: Comment{
+				Contents: " comment",
+				Type:     CommentTypeSingleLine,
+			},
+		},
+		{
+			name: "multiline comment",
+			input: `/* line 1
+line 2 */`,
+			expected: Comment{
+				Contents: " line 1\nline 2 ",
+				Type:     CommentTypeMultiline,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			input := parse.NewInput(tt.input)
+			result, ok, err := commentParser.Parse(input)
+			if err != nil {
+				t.Fatalf("parser error: %v", err)
+			}
+			if !ok {
+				t.Fatalf("failed to parse at %d", input.Index())
+			}
+			if diff := cmp.Diff(tt.expected, result, ignoreNodeRanges); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestCommentParserOnlyParsesTemplCommentsAtTheStartOfALine(t *testing.T) {
+	input := parse.NewInput("text // not a comment")
+	input.Seek(5)
+	_, ok, err := commentParser.Parse(input)
+	if err != nil {
+		t.Fatalf("parser error: %v", err)
+	}
+	if ok {
+		t.Error("expected a comment not to be found")
+	}
+	if input.Index() != 5 {
+		t.Errorf("expected the input not to be consumed, but the index is %d", input.Index())
+	}
+}
+
+func TestCommentParserErrors(t *testing.T) {
+	var tests = []struct {
+		name     string
+		input    string
+		expected error
+	}{
+		{
+			name:  "unclosed html comment",
+			input: `<!-- comment`,
+			expected: parse.Error("html comment: unterminated (missing '-->')",
+				parse.Position{
+					Index: 0,
+					Line:  0,
+					Col:   0,
+				}),
+		},
+		{
+			name:  "unclosed multiline comment",
+			input: `/* comment`,
+			expected: parse.Error("comment: unterminated (missing '*/')",
+				parse.Position{
+					Index: 0,
+					Line:  0,
+					Col:   0,
+				}),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			input := parse.NewInput(tt.input)
+			_, _, err := commentParser.Parse(input)
+			if diff := cmp.Diff(tt.expected, err); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -8,7 +8,12 @@ var conditionalAttributeParser = parse.Func(func(pi *parse.Input) (r Conditional
 	start := pi.Index()
 
 	// Strip leading whitespace and look for `if `.
-	if _, ok, err = parse.All(parse.OptionalWhitespace, parse.String("if ")).Parse(pi); err != nil || !ok {
+	if _, _, err = parse.OptionalWhitespace.Parse(pi); err != nil {
+		pi.Seek(start)
+		return
+	}
+	from := pi.Position()
+	if _, ok, err = parse.String("if ").Parse(pi); err != nil || !ok {
 		pi.Seek(start)
 		return
 	}
@@ -17,6 +22,9 @@ var conditionalAttributeParser = parse.Func(func(pi *parse.Input) (r Conditional
 	if r.Expression, ok, err = Must(ExpressionOf(parse.StringUntil(parse.All(openBraceWithOptionalPadding, parse.NewLine))), "attribute if: unterminated (missing closing '{\n')").Parse(pi); err != nil || !ok {
 		return
 	}
+	if err = validateGo("attribute if", r.Expression, "if ", " {\n}"); err != nil {
+		return r, false, err
+	}
 
 	// Eat " {\n".
 	if _, ok, err = Must(parse.All(openBraceWithOptionalPadding, parse.NewLine), "attribute if: unterminated (missing closing '{')").Parse(pi); err != nil || !ok {
@@ -34,6 +42,18 @@ var conditionalAttributeParser = parse.Func(func(pi *parse.Input) (r Conditional
 		return
 	}
 
+	// Read the optional 'ElseIf' attributes.
+	for {
+		var elseIf ConditionalAttributeElseIf
+		if elseIf, ok, err = attributeElseIfExpression.Parse(pi); err != nil {
+			return
+		}
+		if !ok {
+			break
+		}
+		r.ElseIfs = append(r.ElseIfs, elseIf)
+	}
+
 	// Read the optional 'Else' Nodes.
 	if r.Else, ok, err = attributeElseExpression.Parse(pi); err != nil {
 		return
@@ -50,10 +70,61 @@ var conditionalAttributeParser = parse.Func(func(pi *parse.Input) (r Conditional
 	if _, ok, err = Must(closeBraceWithOptionalPadding, "attribute if: missing end (expected '}')").Parse(pi); err != nil || !ok {
 		return
 	}
+	r.Range = NewRange(from, pi.Position())
 
 	return r, true, nil
 })
 
+var attributeElseIfExpression parse.Parser[ConditionalAttributeElseIf] = attributeElseIfExpressionParser{}
+
+type attributeElseIfExpressionParser struct{}
+
+func (attributeElseIfExpressionParser) Parse(in *parse.Input) (r ConditionalAttributeElseIf, ok bool, err error) {
+	start := in.Index()
+
+	// Strip any initial whitespace.
+	_, _, _ = parse.OptionalWhitespace.Parse(in)
+
+	// } else if
+	if _, ok, err = parse.All(parse.Rune('}'), parse.OptionalWhitespace).Parse(in); err != nil || !ok {
+		in.Seek(start)
+		return
+	}
+	from := in.Position()
+	if _, ok, err = elseIfPrefixParser.Parse(in); err != nil || !ok {
+		in.Seek(start)
+		return
+	}
+
+	// Once we've got a prefix, read until {\n.
+	if r.Expression, ok, err = Must(ExpressionOf(parse.StringUntil(parse.All(openBraceWithOptionalPadding, parse.NewLine))), "attribute else if: unterminated (missing closing '{\n')").Parse(in); err != nil || !ok {
+		return
+	}
+	if err = validateGo("attribute else if", r.Expression, "if ", " {\n}"); err != nil {
+		return r, false, err
+	}
+
+	// Eat " {\n".
+	if _, ok, err = Must(parse.All(openBraceWithOptionalPadding, parse.NewLine), "attribute else if: unterminated (missing closing '{')").Parse(in); err != nil || !ok {
+		return
+	}
+
+	// Else if contents.
+	if r.Then, ok, err = Must[[]Attribute](attributesParser{}, "attribute else if: expected attributes in block, but none were found").Parse(in); err != nil || !ok {
+		return
+	}
+	if len(r.Then) == 0 {
+		err = parse.Error("attribute else if: invalid content or no attributes were found in the else if block", in.Position())
+		return
+	}
+
+	// Clear any optional whitespace.
+	_, _, _ = parse.OptionalWhitespace.Parse(in)
+	r.Range = NewRange(from, in.Position())
+
+	return r, true, nil
+}
+
 var attributeElseExpression parse.Parser[[]Attribute] = attributeElseExpressionParser{}
 
 type attributeElseExpressionParser struct{}
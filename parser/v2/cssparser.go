@@ -11,6 +11,7 @@ import (
 
 // CSS Parser.
 var cssParser = parse.Func(func(pi *parse.Input) (r CSSTemplate, ok bool, err error) {
+	from := pi.Position()
 	r = CSSTemplate{
 		Properties: []CSSProperty{},
 	}
@@ -20,54 +21,143 @@ var cssParser = parse.Func(func(pi *parse.Input) (r CSSTemplate, ok bool, err er
 	if exp, ok, err = cssExpressionParser.Parse(pi); err != nil || !ok {
 		return
 	}
+	r.Type = exp.Type
 	r.Name = exp.Name
+	r.Parameters = exp.Parameters
 
-	for {
-		var cssProperty CSSProperty
+	if r.Properties, ok, err = cssPropertiesParser("css property expression: missing closing brace").Parse(pi); err != nil || !ok {
+		return
+	}
+	r.Range = NewRange(from, pi.Position())
 
-		// Try for an expression CSS declaration.
-		// background-color: { constants.BackgroundColor };
-		cssProperty, ok, err = expressionCSSPropertyParser.Parse(pi)
-		if err != nil {
-			return
-		}
-		if ok {
-			r.Properties = append(r.Properties, cssProperty)
-			continue
-		}
+	if err = validateCSSTemplate(r); err != nil {
+		return r, false, err
+	}
 
-		// Try for a constant CSS declaration.
-		// color: #ffffff;
-		cssProperty, ok, err = constantCSSPropertyParser.Parse(pi)
-		if err != nil {
-			return
-		}
-		if ok {
-			r.Properties = append(r.Properties, cssProperty)
-			continue
-		}
+	return r, true, nil
+})
 
-		// Eat any whitespace.
-		if _, ok, err = parse.OptionalWhitespace.Parse(pi); err != nil || !ok {
-			return
+// validateCSSTemplate checks that keyframes templates only contain keyframe rules, and
+// that fontface templates only contain declarations.
+func validateCSSTemplate(r CSSTemplate) error {
+	for _, p := range r.Properties {
+		rule, isRule := p.(CSSRule)
+		switch r.Type {
+		case CSSTemplateTypeKeyframes:
+			if !isRule {
+				return parse.Error("css keyframes: properties must be inside a keyframe, e.g. from { ... }", cssPropertyPosition(p))
+			}
+			for _, kp := range rule.Properties {
+				if _, isNested := kp.(CSSRule); isNested {
+					return parse.Error("css keyframes: keyframes cannot contain nested rules", cssPropertyPosition(kp))
+				}
+			}
+		case CSSTemplateTypeFontFace:
+			if isRule {
+				return parse.Error("css fontface: nested rules are not allowed", cssPropertyPosition(rule))
+			}
+			if cssPropertyName(p) == "font-family" {
+				return parse.Error("css fontface: font-family is set to the name of the template", cssPropertyPosition(p))
+			}
 		}
+	}
+	return nil
+}
 
-		// Try for }
-		if _, ok, err = Must(closeBraceWithOptionalPadding, "css property expression: missing closing brace").Parse(pi); err != nil || !ok {
-			return
-		}
+func cssPropertyName(p CSSProperty) string {
+	switch p := p.(type) {
+	case ConstantCSSProperty:
+		return p.Name
+	case ExpressionCSSProperty:
+		return p.Name
+	}
+	return ""
+}
 
-		return r, true, nil
+func cssPropertyPosition(p CSSProperty) parse.Position {
+	var r Range
+	switch p := p.(type) {
+	case ConstantCSSProperty:
+		r = p.Range
+	case ExpressionCSSProperty:
+		r = p.Range
+	case CSSRule:
+		r = p.Range
 	}
-})
+	return parse.Position{Index: int(r.From.Index), Line: int(r.From.Line), Col: int(r.From.Col)}
+}
+
+// cssPropertiesParser parses the properties and nested rules of a css template or rule,
+// up to and including the closing brace.
+func cssPropertiesParser(missingCloseBraceMsg string) parse.Parser[[]CSSProperty] {
+	return parse.Func(func(pi *parse.Input) (properties []CSSProperty, ok bool, err error) {
+		properties = []CSSProperty{}
+		for {
+			var cssProperty CSSProperty
+
+			// Try for an expression CSS declaration.
+			// background-color: { constants.BackgroundColor };
+			cssProperty, ok, err = expressionCSSPropertyParser.Parse(pi)
+			if err != nil {
+				return
+			}
+			if ok {
+				properties = append(properties, cssProperty)
+				continue
+			}
+
+			// Try for a nested rule.
+			// &:hover {
+			cssProperty, ok, err = cssRuleParser{}.Parse(pi)
+			if err != nil {
+				return
+			}
+			if ok {
+				properties = append(properties, cssProperty)
+				continue
+			}
+
+			// Try for a constant CSS declaration.
+			// color: #ffffff;
+			cssProperty, ok, err = constantCSSPropertyParser.Parse(pi)
+			if err != nil {
+				return
+			}
+			if ok {
+				properties = append(properties, cssProperty)
+				continue
+			}
+
+			// Eat any whitespace.
+			if _, ok, err = parse.OptionalWhitespace.Parse(pi); err != nil || !ok {
+				return
+			}
+
+			// Try for }
+			if _, ok, err = Must(closeBraceWithOptionalPadding, missingCloseBraceMsg).Parse(pi); err != nil || !ok {
+				return
+			}
+
+			return properties, true, nil
+		}
+	})
+}
 
-// css Func() {
+// css Func(color string) {
+// css keyframes Func() {
 type cssExpression struct {
-	Name Expression
+	Type       CSSTemplateType
+	Name       Expression
+	Parameters Expression
 }
 
 var cssExpressionStartParser = parse.String("css ")
 
+var cssTemplateTypeParser = parse.Any(
+	parse.String("keyframes "),
+	parse.String("fontface "),
+)
+
 var cssExpressionNameParser = parse.Func(func(in *parse.Input) (name string, ok bool, err error) {
 	var c string
 	if c, ok = in.Peek(1); !ok || !unicode.IsLetter(rune(c[0])) {
@@ -84,6 +174,21 @@ var cssExpressionParser = parse.Func(func(pi *parse.Input) (r cssExpression, ok
 		return
 	}
 
+	// keyframes and fontface templates create at-rules instead of classes.
+	var templateType string
+	if templateType, ok, err = cssTemplateTypeParser.Parse(pi); err != nil {
+		return
+	}
+	if ok {
+		_, _, _ = parse.OptionalWhitespace.Parse(pi)
+	}
+	switch templateType {
+	case "keyframes ":
+		r.Type = CSSTemplateTypeKeyframes
+	case "fontface ":
+		r.Type = CSSTemplateTypeFontFace
+	}
+
 	// Once we have the prefix, we must have a name and parameters.
 	// Read the name of the function.
 	from := pi.Position()
@@ -99,17 +204,16 @@ var cssExpressionParser = parse.Func(func(pi *parse.Input) (r cssExpression, ok
 		return
 	}
 
-	// Check there's no parameters.
-	from = pi.Position()
-	if _, ok, err = parse.StringUntil(parse.Rune(')')).Parse(pi); err != nil {
+	// Read the parameters.
+	// color string, pad int)
+	if r.Parameters, ok, err = Must(ExpressionOf(parameters), "css expression: parameters missing close bracket").Parse(pi); err != nil || !ok {
 		return
 	}
-	// If there's no match, the name wasn't correctly terminated.
-	if !ok {
-		return r, ok, parse.Error("css expression: parameters missing close bracket", pi.Position())
-	}
-	if pi.Index()-int(from.Index) > 0 {
-		return r, ok, parse.Error("css expression: found unexpected parameters", pi.Position())
+	// The name and parameters are next to each other in the template, so errors in the
+	// signature are reported at the right position.
+	signature := NewExpression(r.Name.Value+"("+r.Parameters.Value+")", from, pi.Position())
+	if err = validateGoSignature("css expression", signature); err != nil {
+		return r, false, err
 	}
 
 	// Eat ") {".
@@ -125,6 +229,54 @@ var cssExpressionParser = parse.Func(func(pi *parse.Input) (r cssExpression, ok
 	return r, true, nil
 })
 
+// Nested rule parser.
+//
+//	&:hover {
+//	@media (max-width: 600px) {
+type cssRuleParser struct{}
+
+func (cssRuleParser) Parse(pi *parse.Input) (r CSSRule, ok bool, err error) {
+	start := pi.Index()
+
+	// Optional whitespace.
+	if _, ok, err = parse.OptionalWhitespace.Parse(pi); err != nil || !ok {
+		return
+	}
+	from := pi.Position()
+
+	// The selector, or at-rule, is everything up to a { at the end of the line.
+	if r.Selector, ok, err = parse.StringUntil(parse.Any(parse.String("{"), parse.String("}"), parse.String(";"), parse.NewLine)).Parse(pi); err != nil || !ok {
+		pi.Seek(start)
+		return
+	}
+	r.Selector = strings.TrimSpace(r.Selector)
+	if r.Selector == "" {
+		pi.Seek(start)
+		return r, false, nil
+	}
+	if _, ok, err = parse.All(parse.String("{"), optionalSpaces, parse.NewLine).Parse(pi); err != nil || !ok {
+		pi.Seek(start)
+		return
+	}
+	if strings.Contains(r.Selector, "`") {
+		err = parse.Error("css rule: selectors cannot contain backticks", from)
+		return
+	}
+
+	// Properties and nested rules, up to the closing brace.
+	if r.Properties, ok, err = cssPropertiesParser("css rule: missing closing brace").Parse(pi); err != nil || !ok {
+		return
+	}
+	r.Range = NewRange(from, pi.Position())
+
+	// \n
+	if _, ok, err = Must(parse.NewLine, "css rule: missing terminating newline").Parse(pi); err != nil || !ok {
+		return
+	}
+
+	return r, true, nil
+}
+
 // CSS property name parser.
 var cssPropertyNameFirst = "abcdefghijklmnopqrstuvwxyz"
 var cssPropertyNameSubsequent = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-"
@@ -154,6 +306,7 @@ var expressionCSSPropertyParser = parse.Func(func(pi *parse.Input) (r Expression
 	if _, ok, err = parse.OptionalWhitespace.Parse(pi); err != nil || !ok {
 		return
 	}
+	from := pi.Position()
 	// Property name.
 	if r.Name, ok, err = cssPropertyNameParser.Parse(pi); err != nil || !ok {
 		pi.Seek(start)
@@ -175,6 +328,7 @@ var expressionCSSPropertyParser = parse.Func(func(pi *parse.Input) (r Expression
 	if _, ok, err = Must(parse.String(";"), "missing expected semicolon (;)").Parse(pi); err != nil || !ok {
 		return
 	}
+	r.Range = NewRange(from, pi.Position())
 	// \n
 	if _, ok, err = Must(parse.NewLine, "missing expected linebreak").Parse(pi); err != nil || !ok {
 		return
@@ -191,6 +345,7 @@ var constantCSSPropertyParser = parse.Func(func(pi *parse.Input) (r ConstantCSSP
 	if _, ok, err = parse.OptionalWhitespace.Parse(pi); err != nil || !ok {
 		return
 	}
+	from := pi.Position()
 	// Property name.
 	if r.Name, ok, err = cssPropertyNameParser.Parse(pi); err != nil || !ok {
 		pi.Seek(start)
@@ -212,8 +367,14 @@ var constantCSSPropertyParser = parse.Func(func(pi *parse.Input) (r ConstantCSSP
 		return
 	}
 
-	// Chomp the ;\n
-	if _, ok, err = Must(untilEnd, "failed to chomp semicolon and linebreak (;\\n)").Parse(pi); err != nil || !ok {
+	// Chomp the ;
+	if _, ok, err = Must(parse.All(parse.OptionalWhitespace, parse.Rune(';')), "failed to chomp semicolon and linebreak (;\\n)").Parse(pi); err != nil || !ok {
+		return
+	}
+	r.Range = NewRange(from, pi.Position())
+
+	// Chomp the \n
+	if _, ok, err = Must(parse.NewLine, "failed to chomp semicolon and linebreak (;\\n)").Parse(pi); err != nil || !ok {
 		return
 	}
 
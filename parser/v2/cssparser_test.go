@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/a-h/parse"
@@ -73,7 +74,7 @@ func TestExpressionCSSPropertyParser(t *testing.T) {
 			if !ok {
 				t.Fatalf("failed to parse at %d", input.Index())
 			}
-			if diff := cmp.Diff(tt.expected, result); diff != "" {
+			if diff := cmp.Diff(tt.expected, result, ignoreNodeRanges); diff != "" {
 				t.Errorf(diff)
 			}
 		})
@@ -106,7 +107,7 @@ func TestConstantCSSPropertyParser(t *testing.T) {
 			if !ok {
 				t.Fatalf("failed to parse at %d", input.Index())
 			}
-			if diff := cmp.Diff(tt.expected, result); diff != "" {
+			if diff := cmp.Diff(tt.expected, result, ignoreNodeRanges); diff != "" {
 				t.Errorf(diff)
 			}
 		})
@@ -139,6 +140,21 @@ func TestCSSParser(t *testing.T) {
 						},
 					},
 				},
+				Parameters: Expression{
+					Value: "",
+					Range: Range{
+						From: Position{
+							Index: 9,
+							Line:  0,
+							Col:   9,
+						},
+						To: Position{
+							Index: 9,
+							Line:  0,
+							Col:   9,
+						},
+					},
+				},
 				Properties: []CSSProperty{},
 			},
 		},
@@ -162,6 +178,21 @@ func TestCSSParser(t *testing.T) {
 						},
 					},
 				},
+				Parameters: Expression{
+					Value: "",
+					Range: Range{
+						From: Position{
+							Index: 9,
+							Line:  0,
+							Col:   9,
+						},
+						To: Position{
+							Index: 9,
+							Line:  0,
+							Col:   9,
+						},
+					},
+				},
 				Properties: []CSSProperty{},
 			},
 		},
@@ -186,6 +217,21 @@ background-color: #ffffff;
 						},
 					},
 				},
+				Parameters: Expression{
+					Value: "",
+					Range: Range{
+						From: Position{
+							Index: 9,
+							Line:  0,
+							Col:   9,
+						},
+						To: Position{
+							Index: 9,
+							Line:  0,
+							Col:   9,
+						},
+					},
+				},
 				Properties: []CSSProperty{
 					ConstantCSSProperty{
 						Name:  "background-color",
@@ -215,6 +261,21 @@ background-color: { constants.BackgroundColor };
 						},
 					},
 				},
+				Parameters: Expression{
+					Value: "",
+					Range: Range{
+						From: Position{
+							Index: 9,
+							Line:  0,
+							Col:   9,
+						},
+						To: Position{
+							Index: 9,
+							Line:  0,
+							Col:   9,
+						},
+					},
+				},
 				Properties: []CSSProperty{
 					ExpressionCSSProperty{
 						Name: "background-color",
@@ -239,6 +300,298 @@ background-color: { constants.BackgroundColor };
 				},
 			},
 		},
+		{
+			name: "css: parameters",
+			input: `css Name(color string, pad int) {
+	color: { color };
+}`,
+			expected: CSSTemplate{
+				Name: Expression{
+					Value: "Name",
+					Range: Range{
+						From: Position{
+							Index: 4,
+							Line:  0,
+							Col:   4,
+						},
+						To: Position{
+							Index: 8,
+							Line:  0,
+							Col:   8,
+						},
+					},
+				},
+				Parameters: Expression{
+					Value: "color string, pad int",
+					Range: Range{
+						From: Position{
+							Index: 9,
+							Line:  0,
+							Col:   9,
+						},
+						To: Position{
+							Index: 30,
+							Line:  0,
+							Col:   30,
+						},
+					},
+				},
+				Properties: []CSSProperty{
+					ExpressionCSSProperty{
+						Name: "color",
+						Value: StringExpression{
+							Expression: Expression{
+								Value: "color",
+								Range: Range{
+									From: Position{
+										Index: 44,
+										Line:  1,
+										Col:   10,
+									},
+									To: Position{
+										Index: 49,
+										Line:  1,
+										Col:   15,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "css: parameters with brackets in their types",
+			input: `css Name(f func(int) string, m map[string]struct{ a [2]int }, s string) {
+	color: { f(1) };
+}`,
+			expected: CSSTemplate{
+				Name: Expression{
+					Value: "Name",
+					Range: Range{
+						From: Position{
+							Index: 4,
+							Line:  0,
+							Col:   4,
+						},
+						To: Position{
+							Index: 8,
+							Line:  0,
+							Col:   8,
+						},
+					},
+				},
+				Parameters: Expression{
+					Value: "f func(int) string, m map[string]struct{ a [2]int }, s string",
+					Range: Range{
+						From: Position{
+							Index: 9,
+							Line:  0,
+							Col:   9,
+						},
+						To: Position{
+							Index: 70,
+							Line:  0,
+							Col:   70,
+						},
+					},
+				},
+				Properties: []CSSProperty{
+					ExpressionCSSProperty{
+						Name: "color",
+						Value: StringExpression{
+							Expression: Expression{
+								Value: "f(1)",
+								Range: Range{
+									From: Position{
+										Index: 84,
+										Line:  1,
+										Col:   10,
+									},
+									To: Position{
+										Index: 88,
+										Line:  1,
+										Col:   14,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "css: nested rules",
+			input: `css Name() {
+	color: #000000;
+	&:hover {
+		color: #ff0000;
+	}
+	@media (max-width: 600px) {
+		li, > a {
+			display: block;
+		}
+	}
+}`,
+			expected: CSSTemplate{
+				Name: Expression{
+					Value: "Name",
+					Range: Range{
+						From: Position{
+							Index: 4,
+							Line:  0,
+							Col:   4,
+						},
+						To: Position{
+							Index: 8,
+							Line:  0,
+							Col:   8,
+						},
+					},
+				},
+				Parameters: Expression{
+					Value: "",
+					Range: Range{
+						From: Position{
+							Index: 9,
+							Line:  0,
+							Col:   9,
+						},
+						To: Position{
+							Index: 9,
+							Line:  0,
+							Col:   9,
+						},
+					},
+				},
+				Properties: []CSSProperty{
+					ConstantCSSProperty{
+						Name:  "color",
+						Value: "#000000",
+					},
+					CSSRule{
+						Selector: "&:hover",
+						Properties: []CSSProperty{
+							ConstantCSSProperty{
+								Name:  "color",
+								Value: "#ff0000",
+							},
+						},
+					},
+					CSSRule{
+						Selector: "@media (max-width: 600px)",
+						Properties: []CSSProperty{
+							CSSRule{
+								Selector: "li, > a",
+								Properties: []CSSProperty{
+									ConstantCSSProperty{
+										Name:  "display",
+										Value: "block",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "css: keyframes",
+			input: `css keyframes Name() {
+	from {
+		opacity: 0;
+	}
+}`,
+			expected: CSSTemplate{
+				Type: CSSTemplateTypeKeyframes,
+				Name: Expression{
+					Value: "Name",
+					Range: Range{
+						From: Position{
+							Index: 14,
+							Line:  0,
+							Col:   14,
+						},
+						To: Position{
+							Index: 18,
+							Line:  0,
+							Col:   18,
+						},
+					},
+				},
+				Parameters: Expression{
+					Value: "",
+					Range: Range{
+						From: Position{
+							Index: 19,
+							Line:  0,
+							Col:   19,
+						},
+						To: Position{
+							Index: 19,
+							Line:  0,
+							Col:   19,
+						},
+					},
+				},
+				Properties: []CSSProperty{
+					CSSRule{
+						Selector: "from",
+						Properties: []CSSProperty{
+							ConstantCSSProperty{
+								Name:  "opacity",
+								Value: "0",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "css: fontface",
+			input: `css fontface Name() {
+	font-weight: 400;
+}`,
+			expected: CSSTemplate{
+				Type: CSSTemplateTypeFontFace,
+				Name: Expression{
+					Value: "Name",
+					Range: Range{
+						From: Position{
+							Index: 13,
+							Line:  0,
+							Col:   13,
+						},
+						To: Position{
+							Index: 17,
+							Line:  0,
+							Col:   17,
+						},
+					},
+				},
+				Parameters: Expression{
+					Value: "",
+					Range: Range{
+						From: Position{
+							Index: 18,
+							Line:  0,
+							Col:   18,
+						},
+						To: Position{
+							Index: 18,
+							Line:  0,
+							Col:   18,
+						},
+					},
+				},
+				Properties: []CSSProperty{
+					ConstantCSSProperty{
+						Name:  "font-weight",
+						Value: "400",
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -251,9 +604,95 @@ background-color: { constants.BackgroundColor };
 			if !ok {
 				t.Fatalf("failed to parse at %d", input.Index())
 			}
-			if diff := cmp.Diff(tt.expected, result); diff != "" {
+			if diff := cmp.Diff(tt.expected, result, ignoreNodeRanges); diff != "" {
 				t.Errorf(diff)
 			}
 		})
 	}
 }
+
+func TestCSSParserErrors(t *testing.T) {
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name: "css: unclosed nested rule",
+			input: `css Name() {
+	&:hover {
+		color: #ff0000;
+`,
+			expected: "css rule: missing closing brace",
+		},
+		{
+			name: "css: invalid parameters",
+			input: `css Name(color string pad int) {
+	color: { color };
+}`,
+			expected: "css expression: missing ',' in parameter list: line 0, col 22",
+		},
+		{
+			name: "css: unclosed parameters",
+			input: `css Name(f func(int string {
+	color: red;
+}`,
+			expected: "css expression: parameters missing close bracket",
+		},
+		{
+			name: "css: backticks in selectors",
+			input: `css Name() {
+	&[title="` + "`" + `"] {
+		color: #ff0000;
+	}
+}`,
+			expected: "css rule: selectors cannot contain backticks",
+		},
+		{
+			name: "css keyframes: properties outside a keyframe",
+			input: `css keyframes Name() {
+	opacity: 0;
+}`,
+			expected: "css keyframes: properties must be inside a keyframe",
+		},
+		{
+			name: "css keyframes: nested rules",
+			input: `css keyframes Name() {
+	from {
+		&:hover {
+			opacity: 0;
+		}
+	}
+}`,
+			expected: "css keyframes: keyframes cannot contain nested rules",
+		},
+		{
+			name: "css fontface: nested rules",
+			input: `css fontface Name() {
+	&:hover {
+		opacity: 0;
+	}
+}`,
+			expected: "css fontface: nested rules are not allowed",
+		},
+		{
+			name: "css fontface: font-family",
+			input: `css fontface Name() {
+	font-family: "Brand";
+}`,
+			expected: "css fontface: font-family is set to the name of the template",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := cssParser.Parse(parse.NewInput(tt.input))
+			if err == nil {
+				t.Fatal("expected an error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("expected error %q, got %q", tt.expected, err.Error())
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 var doctypeStartParser = parse.StringInsensitive("<!doctype ")
 
 var docTypeParser = parse.Func(func(pi *parse.Input) (r DocType, ok bool, err error) {
+	from := pi.Position()
 	if _, ok, err = doctypeStartParser.Parse(pi); err != nil || !ok {
 		return
 	}
@@ -20,6 +21,7 @@ var docTypeParser = parse.Func(func(pi *parse.Input) (r DocType, ok bool, err er
 	if _, ok, err = Must(gt, "unclosed DOCTYPE").Parse(pi); err != nil || !ok {
 		return
 	}
+	r.Range = NewRange(from, pi.Position())
 
 	return r, true, nil
 })
@@ -53,7 +53,7 @@ func TestDocTypeParser(t *testing.T) {
 			if !ok {
 				t.Fatalf("failed to parse at %d", input.Index())
 			}
-			if diff := cmp.Diff(tt.expected, result); diff != "" {
+			if diff := cmp.Diff(tt.expected, result, ignoreNodeRanges); diff != "" {
 				t.Errorf(diff)
 			}
 		})
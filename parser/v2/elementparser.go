@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"html"
 	"strings"
+	"unicode"
 
 	"github.com/a-h/parse"
 )
@@ -72,26 +73,28 @@ var elementCloseTagParser = parse.Func(func(in *parse.Input) (ect elementCloseTa
 })
 
 // Attribute name.
-var attributeNameFirst = "abcdefghijklmnopqrstuvwxyz"
-var attributeNameSubsequent = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-"
+// Names can contain any character that HTML allows, so that framework attributes such as
+// @click, :class and hx-on::after-request can be used. Braces, question marks, angle
+// brackets and backslashes are also excluded, since they're part of templ's syntax, or
+// would need to be escaped in the generated Go code.
 var attributeNameParser = parse.Func(func(in *parse.Input) (name string, ok bool, err error) {
 	start := in.Index()
-	var prefix, suffix string
-	if prefix, ok, err = parse.RuneIn(attributeNameFirst).Parse(in); err != nil || !ok {
-		return
-	}
-	if suffix, ok, err = parse.StringUntil(parse.RuneNotIn(attributeNameSubsequent)).Parse(in); err != nil {
+	if name, ok, err = parse.StringUntilEOF(parse.RuneWhere(isAttributeNameTerminator)).Parse(in); err != nil || !ok || name == "" {
 		in.Seek(start)
-		return
+		return "", false, err
 	}
-	if len(suffix)+1 > 128 {
+	if len(name) > 128 {
 		ok = false
 		err = parse.Error("attribute names must be < 128 characters long", in.Position())
 		return
 	}
-	return prefix + suffix, true, nil
+	return name, true, nil
 })
 
+func isAttributeNameTerminator(r rune) bool {
+	return unicode.IsSpace(r) || unicode.IsControl(r) || strings.ContainsRune(`"'/<=>?\{}`, r)
+}
+
 // Constant attribute.
 var attributeConstantValueParser = parse.StringUntil(parse.Rune('"'))
 var constantAttributeParser = parse.Func(func(pi *parse.Input) (attr ConstantAttribute, ok bool, err error) {
@@ -101,6 +104,7 @@ var constantAttributeParser = parse.Func(func(pi *parse.Input) (attr ConstantAtt
 	if _, ok, err = parse.OptionalWhitespace.Parse(pi); err != nil || !ok {
 		return
 	}
+	from := pi.Position()
 
 	// Attribute name.
 	if attr.Name, ok, err = attributeNameParser.Parse(pi); err != nil || !ok {
@@ -127,10 +131,169 @@ var constantAttributeParser = parse.Func(func(pi *parse.Input) (attr ConstantAtt
 		pi.Seek(start)
 		return
 	}
+	attr.Range = NewRange(from, pi.Position())
+
+	return attr, true, nil
+})
+
+// Interpolated attribute.
+var interpolationStart = parse.Or(parse.String("{ "), parse.String("{"))
+
+// interpolatedAttributeParser parses quoted attribute values that contain Go expressions,
+// e.g. href="/users/{ id }/edit". Only the attributes listed by allowsInterpolation can
+// contain expressions, and braces that don't contain a valid Go expression are part of
+// the text, so that values such as class="{ a: b }" are constant.
+var interpolatedAttributeParser = parse.Func(func(pi *parse.Input) (attr InterpolatedAttribute, ok bool, err error) {
+	start := pi.Index()
+
+	// Optional whitespace leader.
+	if _, ok, err = parse.OptionalWhitespace.Parse(pi); err != nil || !ok {
+		return
+	}
+	from := pi.Position()
+
+	// Attribute name.
+	if attr.Name, ok, err = attributeNameParser.Parse(pi); err != nil || !ok {
+		pi.Seek(start)
+		return
+	}
+	if !allowsInterpolation(attr.Name) {
+		pi.Seek(start)
+		return attr, false, nil
+	}
+
+	// ="
+	if _, ok, err = parse.String(`="`).Parse(pi); err != nil || !ok {
+		pi.Seek(start)
+		return
+	}
+
+	// Attribute value.
+	var literal strings.Builder
+	var hasExpression bool
+	for {
+		next, _ := pi.Peek(1)
+		if next == `"` || next == "" {
+			break
+		}
+		if next == "{" {
+			var e Expression
+			if e, ok = interpolation(pi); ok {
+				if literal.Len() > 0 {
+					attr.Parts = append(attr.Parts, InterpolatedAttributePart{Value: html.UnescapeString(literal.String())})
+					literal.Reset()
+				}
+				attr.Parts = append(attr.Parts, InterpolatedAttributePart{Expression: e})
+				hasExpression = true
+				continue
+			}
+		}
+		c, _ := pi.Take(1)
+		literal.WriteString(c)
+	}
+	if !hasExpression {
+		// Constant attributes, and attributes that aren't closed, are parsed by the constant attribute parser.
+		pi.Seek(start)
+		return attr, false, nil
+	}
+	if literal.Len() > 0 {
+		attr.Parts = append(attr.Parts, InterpolatedAttributePart{Value: html.UnescapeString(literal.String())})
+	}
+
+	// " - closing quote.
+	if _, ok, err = Must(parse.String(`"`), fmt.Sprintf("missing closing quote on attribute %q", attr.Name)).Parse(pi); err != nil || !ok {
+		pi.Seek(start)
+		return
+	}
+	attr.Range = NewRange(from, pi.Position())
 
 	return attr, true, nil
 })
 
+// interpolation parses a Go expression within braces. If the braces don't contain a
+// valid Go expression, the input isn't consumed.
+func interpolation(pi *parse.Input) (e Expression, ok bool) {
+	start := pi.Index()
+	if _, ok, _ = interpolationStart.Parse(pi); !ok {
+		return
+	}
+	var err error
+	if e, ok, err = exp.Parse(pi); err != nil || !ok || strings.TrimSpace(e.Value) == "" || validateGoExpression("", e) != nil {
+		pi.Seek(start)
+		return e, false
+	}
+	if _, ok, err = closeBraceWithOptionalPadding.Parse(pi); err != nil || !ok {
+		pi.Seek(start)
+		return e, false
+	}
+	return e, true
+}
+
+// allowsInterpolation returns true for attributes that can contain expressions. Other
+// attributes, such as style, event handlers and the attributes used by frameworks like
+// Alpine.js, Vue, htmx and hyperscript, can contain CSS or JavaScript, where HTML escaping
+// isn't enough to make the values of expressions safe.
+func allowsInterpolation(name string) bool {
+	name = strings.ToLower(name)
+	if strings.HasPrefix(name, "aria-") {
+		return true
+	}
+	if strings.HasPrefix(name, "data-") {
+		// htmx attributes can also be written as data-hx-*.
+		return !strings.HasPrefix(name, "data-hx-")
+	}
+	_, ok := interpolatedAttributes[name]
+	return ok
+}
+
+// interpolatedAttributes contain text, identifiers or URLs.
+var interpolatedAttributes = map[string]struct{}{
+	"abbr": {}, "accept": {}, "action": {}, "alt": {}, "autocomplete": {}, "cite": {}, "class": {}, "content": {}, "datetime": {},
+	"download": {}, "for": {}, "form": {}, "formaction": {}, "headers": {}, "height": {}, "href": {}, "hreflang": {}, "id": {},
+	"label": {}, "lang": {}, "list": {}, "max": {}, "media": {}, "min": {}, "name": {}, "pattern": {}, "placeholder": {}, "poster": {},
+	"rel": {}, "role": {}, "sizes": {}, "slot": {}, "src": {}, "srcset": {}, "step": {}, "target": {}, "title": {}, "type": {},
+	"value": {}, "width": {},
+}
+
+// IsEventHandlerAttribute returns true if the attribute is a DOM event handler, such as
+// onclick, which contains JavaScript.
+func IsEventHandlerAttribute(name string) bool {
+	_, ok := eventHandlerAttributes[strings.ToLower(name)]
+	return ok
+}
+
+// https://html.spec.whatwg.org/multipage/webappapis.html#event-handlers-on-elements,-document-objects,-and-window-objects
+// https://www.w3.org/TR/pointerevents/#extensions-to-the-globaleventhandlers-mixin
+var eventHandlerAttributes = map[string]struct{}{
+	"onabort": {}, "onafterprint": {}, "onanimationcancel": {}, "onanimationend": {}, "onanimationiteration": {}, "onanimationstart": {}, "onauxclick": {},
+	"onbeforeinput": {}, "onbeforematch": {}, "onbeforeprint": {}, "onbeforetoggle": {}, "onbeforeunload": {}, "onblur": {},
+	"oncancel": {}, "oncanplay": {}, "oncanplaythrough": {}, "onchange": {}, "onclick": {}, "onclose": {}, "oncontextlost": {}, "oncontextmenu": {},
+	"oncontextrestored": {}, "oncopy": {}, "oncuechange": {}, "oncut": {},
+	"ondblclick": {}, "ondrag": {}, "ondragend": {}, "ondragenter": {}, "ondragleave": {}, "ondragover": {}, "ondragstart": {}, "ondrop": {}, "ondurationchange": {},
+	"onemptied": {}, "onended": {}, "onerror": {}, "onfocus": {}, "onfocusin": {}, "onfocusout": {}, "onformdata": {}, "ongotpointercapture": {}, "onhashchange": {},
+	"oninput": {}, "oninvalid": {}, "onkeydown": {}, "onkeypress": {}, "onkeyup": {}, "onlanguagechange": {},
+	"onload": {}, "onloadeddata": {}, "onloadedmetadata": {}, "onloadstart": {}, "onlostpointercapture": {},
+	"onmessage": {}, "onmessageerror": {}, "onmousedown": {}, "onmouseenter": {}, "onmouseleave": {}, "onmousemove": {}, "onmouseout": {}, "onmouseover": {}, "onmouseup": {},
+	"onoffline": {}, "ononline": {}, "onpagehide": {}, "onpagereveal": {}, "onpageshow": {}, "onpageswap": {}, "onpaste": {}, "onpause": {}, "onplay": {}, "onplaying": {},
+	"onpointercancel": {}, "onpointerdown": {}, "onpointerenter": {}, "onpointerleave": {}, "onpointermove": {}, "onpointerout": {}, "onpointerover": {}, "onpointerup": {},
+	"onpopstate": {}, "onprogress": {}, "onratechange": {}, "onrejectionhandled": {}, "onreset": {}, "onresize": {},
+	"onscroll": {}, "onscrollend": {}, "onsecuritypolicyviolation": {}, "onseeked": {}, "onseeking": {}, "onselect": {}, "onselectionchange": {}, "onselectstart": {},
+	"onslotchange": {}, "onstalled": {}, "onstorage": {}, "onsubmit": {}, "onsuspend": {},
+	"ontimeupdate": {}, "ontoggle": {}, "ontouchcancel": {}, "ontouchend": {}, "ontouchmove": {}, "ontouchstart": {},
+	"ontransitioncancel": {}, "ontransitionend": {}, "ontransitionrun": {}, "ontransitionstart": {},
+	"onunhandledrejection": {}, "onunload": {}, "onvolumechange": {}, "onwaiting": {}, "onwheel": {},
+}
+
+// containsInterpolation returns true if a constant attribute value would be parsed as an
+// interpolated attribute, once written out.
+func containsInterpolation(name, value string) bool {
+	if !allowsInterpolation(name) || !strings.Contains(value, "{") {
+		return false
+	}
+	_, ok, _ := interpolatedAttributeParser.Parse(parse.NewInput(name + `="` + html.EscapeString(value) + `"`))
+	return ok
+}
+
 // BoolConstantAttribute.
 var boolConstantAttributeParser = parse.Func(func(pi *parse.Input) (attr BoolConstantAttribute, ok bool, err error) {
 	start := pi.Index()
@@ -139,12 +302,14 @@ var boolConstantAttributeParser = parse.Func(func(pi *parse.Input) (attr BoolCon
 	if _, ok, err = parse.OptionalWhitespace.Parse(pi); err != nil || !ok {
 		return
 	}
+	from := pi.Position()
 
 	// Attribute name.
 	if attr.Name, ok, err = attributeNameParser.Parse(pi); err != nil || !ok {
 		pi.Seek(start)
 		return
 	}
+	attr.Range = NewRange(from, pi.Position())
 
 	// We have a name, but if we have an equals sign, it's not a constant boolean attribute.
 	next, ok := pi.Peek(1)
@@ -157,8 +322,8 @@ var boolConstantAttributeParser = parse.Func(func(pi *parse.Input) (attr BoolCon
 		pi.Seek(start)
 		return attr, false, nil
 	}
-	if !(next == " " || next == "\t" || next == "\n" || next == "/") {
-		err = parse.Error(fmt.Sprintf("boolConstantAttributeParser: expected attribute name to end with space, newline or '/>', but got %q", next), pi.Position())
+	if !(next == " " || next == "\t" || next == "\r" || next == "\n" || next == "/" || next == ">") {
+		err = parse.Error(fmt.Sprintf("boolConstantAttributeParser: expected attribute name to end with space, newline, '>' or '/>', but got %q", next), pi.Position())
 		return attr, false, err
 	}
 
@@ -176,6 +341,7 @@ var boolExpressionAttributeParser = parse.Func(func(pi *parse.Input) (r BoolExpr
 		pi.Seek(start)
 		return
 	}
+	from := pi.Position()
 
 	// Attribute name.
 	if r.Name, ok, err = attributeNameParser.Parse(pi); err != nil || !ok {
@@ -194,12 +360,16 @@ var boolExpressionAttributeParser = parse.Func(func(pi *parse.Input) (r BoolExpr
 		pi.Seek(start)
 		return
 	}
+	if err = validateGoExpression(fmt.Sprintf("attribute %q", r.Name), r.Expression); err != nil {
+		return r, false, err
+	}
 
 	// Eat the Final brace.
 	if _, ok, err = Must(closeBraceWithOptionalPadding, "boolean expression: missing closing brace").Parse(pi); err != nil || !ok {
 		pi.Seek(start)
 		return
 	}
+	r.Range = NewRange(from, pi.Position())
 
 	return r, true, nil
 })
@@ -211,6 +381,7 @@ var expressionAttributeParser = parse.Func(func(pi *parse.Input) (attr Expressio
 	if _, ok, err = parse.OptionalWhitespace.Parse(pi); err != nil || !ok {
 		return
 	}
+	from := pi.Position()
 
 	// Attribute name.
 	if attr.Name, ok, err = attributeNameParser.Parse(pi); err != nil || !ok {
@@ -229,12 +400,16 @@ var expressionAttributeParser = parse.Func(func(pi *parse.Input) (attr Expressio
 		pi.Seek(start)
 		return
 	}
+	if err = validateExpressionAttribute(attr); err != nil {
+		return attr, false, err
+	}
 
 	// Eat the final brace.
 	if _, ok, err = Must(closeBraceWithOptionalPadding, "boolean expression: missing closing brace").Parse(pi); err != nil || !ok {
 		pi.Seek(start)
 		return
 	}
+	attr.Range = NewRange(from, pi.Position())
 
 	return attr, true, nil
 })
@@ -255,6 +430,9 @@ func (attributeParser) Parse(in *parse.Input) (out Attribute, ok bool, err error
 	if out, ok, err = boolConstantAttributeParser.Parse(in); err != nil || ok {
 		return
 	}
+	if out, ok, err = interpolatedAttributeParser.Parse(in); err != nil || ok {
+		return
+	}
 	if out, ok, err = constantAttributeParser.Parse(in); err != nil || ok {
 		return
 	}
@@ -315,7 +493,8 @@ func (elementOpenCloseParser) Parse(pi *parse.Input) (r Element, ok bool, err er
 	r.Attributes = ot.Attributes
 
 	// Once we've got an open tag, the rest must be present.
-	if r.Children, ok, err = newTemplateNodeParser[any](nil, "").Parse(pi); err != nil || !ok {
+	var errs Errors
+	if r.Children, ok, err = newTemplateNodeParser[any](nil, "").Parse(pi); !errs.add(err) || !ok {
 		return
 	}
 
@@ -335,7 +514,7 @@ func (elementOpenCloseParser) Parse(pi *parse.Input) (r Element, ok bool, err er
 		return
 	}
 
-	return r, true, nil
+	return r, true, errs.orNil()
 }
 
 // Element self-closing tag.
@@ -380,13 +559,15 @@ type elementParser struct{}
 func (elementParser) Parse(pi *parse.Input) (r Element, ok bool, err error) {
 	start := pi.Position()
 
-	if r, ok, err = parse.Any[Element](selfClosingElement, elementOpenClose).Parse(pi); err != nil || !ok {
+	var errs Errors
+	if r, ok, err = parse.Any[Element](selfClosingElement, elementOpenClose).Parse(pi); !errs.add(err) || !ok {
 		return
 	}
+	r.Range = NewRange(start, pi.Position())
 	var msgs []string
 	if msgs, ok = r.Validate(); !ok {
-		err = parse.Error(fmt.Sprintf("<%s>: %s", r.Name, strings.Join(msgs, ", ")), start)
+		return r, false, parse.Error(fmt.Sprintf("<%s>: %s", r.Name, strings.Join(msgs, ", ")), start)
 	}
 
-	return r, ok, err
+	return r, true, errs.orNil()
 }
@@ -225,6 +225,49 @@ if test {
 				Value: "value",
 			},
 		},
+		{
+			name:   "element: framework attribute names",
+			input:  `<form @click="open = !open" :class="{ 'active': open }" x-on:submit.prevent="save()" hx-on::after-request={ reset } hidden>`,
+			parser: StripType(elementOpenTagParser),
+			expected: elementOpenTag{
+				Name: "form",
+				Attributes: []Attribute{
+					ConstantAttribute{
+						Name:  "@click",
+						Value: "open = !open",
+					},
+					ConstantAttribute{
+						Name:  ":class",
+						Value: "{ 'active': open }",
+					},
+					ConstantAttribute{
+						Name:  "x-on:submit.prevent",
+						Value: "save()",
+					},
+					ExpressionAttribute{
+						Name: "hx-on::after-request",
+						Expression: Expression{
+							Value: "reset",
+							Range: Range{
+								From: Position{
+									Index: 108,
+									Line:  0,
+									Col:   108,
+								},
+								To: Position{
+									Index: 113,
+									Line:  0,
+									Col:   113,
+								},
+							},
+						},
+					},
+					BoolConstantAttribute{
+						Name: "hidden",
+					},
+				},
+			},
+		},
 		{
 			name:   "empty attribute",
 			input:  ` data=""`,
@@ -243,6 +286,139 @@ if test {
 				Value: `<">`,
 			},
 		},
+		{
+			name:   "interpolated attribute",
+			input:  ` href="/users/{ id }/edit?a=1&amp;b={fmt.Sprint("x")}"`,
+			parser: StripType(interpolatedAttributeParser),
+			expected: InterpolatedAttribute{
+				Name: "href",
+				Parts: []InterpolatedAttributePart{
+					{Value: "/users/"},
+					{
+						Expression: Expression{
+							Value: "id",
+							Range: Range{
+								From: Position{Index: 16, Line: 0, Col: 16},
+								To:   Position{Index: 18, Line: 0, Col: 18},
+							},
+						},
+					},
+					{Value: "/edit?a=1&b="},
+					{
+						Expression: Expression{
+							Value: `fmt.Sprint("x")`,
+							Range: Range{
+								From: Position{Index: 37, Line: 0, Col: 37},
+								To:   Position{Index: 52, Line: 0, Col: 52},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "interpolated attribute: braces that don't contain Go expressions are text",
+			input:  ` class="{ a: b } card-{ variant } &#123; x }"`,
+			parser: StripType(interpolatedAttributeParser),
+			expected: InterpolatedAttribute{
+				Name: "class",
+				Parts: []InterpolatedAttributePart{
+					{Value: "{ a: b } card-"},
+					{
+						Expression: Expression{
+							Value: "variant",
+							Range: Range{
+								From: Position{Index: 24, Line: 0, Col: 24},
+								To:   Position{Index: 31, Line: 0, Col: 31},
+							},
+						},
+					},
+					{Value: " { x }"},
+				},
+			},
+		},
+		{
+			name:   "constant attribute: braces that don't contain Go expressions",
+			input:  ` x-data="{ open: false }"`,
+			parser: StripType[Attribute](attributeParser{}),
+			expected: ConstantAttribute{
+				Name:  "x-data",
+				Value: "{ open: false }",
+			},
+		},
+		{
+			name:   "constant attribute: expressions aren't interpolated in event handlers",
+			input:  ` onclick="alert({ a })"`,
+			parser: StripType[Attribute](attributeParser{}),
+			expected: ConstantAttribute{
+				Name:  "onclick",
+				Value: "alert({ a })",
+			},
+		},
+		{
+			name:   "constant attribute: expressions aren't interpolated in Vue attributes",
+			input:  ` v-bind:class="{ active }"`,
+			parser: StripType[Attribute](attributeParser{}),
+			expected: ConstantAttribute{
+				Name:  "v-bind:class",
+				Value: "{ active }",
+			},
+		},
+		{
+			name:   "constant attribute: expressions aren't interpolated in htmx values",
+			input:  ` hx-vals="{ id }"`,
+			parser: StripType[Attribute](attributeParser{}),
+			expected: ConstantAttribute{
+				Name:  "hx-vals",
+				Value: "{ id }",
+			},
+		},
+		{
+			name:   "constant attribute: expressions aren't interpolated in data-hx attributes",
+			input:  ` data-hx-vals="{ id }"`,
+			parser: StripType[Attribute](attributeParser{}),
+			expected: ConstantAttribute{
+				Name:  "data-hx-vals",
+				Value: "{ id }",
+			},
+		},
+		{
+			name:   "constant attribute: expressions aren't interpolated in hyperscript",
+			input:  ` _="on click set x to { y }"`,
+			parser: StripType[Attribute](attributeParser{}),
+			expected: ConstantAttribute{
+				Name:  "_",
+				Value: "on click set x to { y }",
+			},
+		},
+		{
+			name:   "interpolated attribute: data attributes",
+			input:  ` data-id="{ id }"`,
+			parser: StripType[Attribute](attributeParser{}),
+			expected: InterpolatedAttribute{
+				Name: "data-id",
+				Parts: []InterpolatedAttributePart{
+					{
+						Expression: Expression{
+							Value: "id",
+							Range: Range{
+								From: Position{Index: 12, Line: 0, Col: 12},
+								To:   Position{Index: 14, Line: 0, Col: 14},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "constant attribute: expressions aren't interpolated in framework attributes",
+			input:  ` :class="{ open }"`,
+			parser: StripType[Attribute](attributeParser{}),
+			expected: ConstantAttribute{
+				Name:  ":class",
+				Value: "{ open }",
+			},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -255,7 +431,7 @@ if test {
 			if !ok {
 				t.Errorf("failed to parse at %v", input.Position())
 			}
-			if diff := cmp.Diff(tt.expected, result); diff != "" {
+			if diff := cmp.Diff(tt.expected, result, ignoreNodeRanges); diff != "" {
 				t.Errorf(diff)
 			}
 		})
@@ -579,6 +755,78 @@ func TestElementParser(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "element: self-closing with conditional attribute with else if blocks",
+			input: `<hr
+			if a {
+				class="a"
+			} else if b {
+				class="b"
+			} else if c {
+				class="c"
+			} else {
+				class="d"
+			}
+/>`,
+			expected: Element{
+				Name: "hr",
+				Attributes: []Attribute{
+					ConditionalAttribute{
+						Expression: Expression{
+							Value: "a",
+							Range: Range{
+								From: Position{Index: 10, Line: 1, Col: 6},
+								To:   Position{Index: 11, Line: 1, Col: 7},
+							},
+						},
+						Then: []Attribute{
+							ConstantAttribute{
+								Name:  "class",
+								Value: "a",
+							},
+						},
+						ElseIfs: []ConditionalAttributeElseIf{
+							{
+								Expression: Expression{
+									Value: "b",
+									Range: Range{
+										From: Position{Index: 41, Line: 3, Col: 13},
+										To:   Position{Index: 42, Line: 3, Col: 14},
+									},
+								},
+								Then: []Attribute{
+									ConstantAttribute{
+										Name:  "class",
+										Value: "b",
+									},
+								},
+							},
+							{
+								Expression: Expression{
+									Value: "c",
+									Range: Range{
+										From: Position{Index: 72, Line: 5, Col: 13},
+										To:   Position{Index: 73, Line: 5, Col: 14},
+									},
+								},
+								Then: []Attribute{
+									ConstantAttribute{
+										Name:  "class",
+										Value: "c",
+									},
+								},
+							},
+						},
+						Else: []Attribute{
+							ConstantAttribute{
+								Name:  "class",
+								Value: "d",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "element: open and close with conditional attribute",
 			input: `<p style="padding: 10px" 
@@ -747,7 +995,7 @@ func TestElementParser(t *testing.T) {
 			if !ok {
 				t.Fatalf("failed to parse at %d", input.Index())
 			}
-			if diff := cmp.Diff(tt.expected, result); diff != "" {
+			if diff := cmp.Diff(tt.expected, result, ignoreNodeRanges); diff != "" {
 				t.Errorf(diff)
 			}
 		})
@@ -0,0 +1,99 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/a-h/parse"
+)
+
+// Error is an error that the parser recovered from. Pos is where the error was
+// found, and Range covers the source that the parser skipped to carry on parsing.
+type Error struct {
+	Msg   string
+	Pos   Position
+	Range Range
+}
+
+func (e Error) Error() string {
+	return fmt.Sprintf("%s: line %d, col %d", e.Msg, e.Pos.Line, e.Pos.Col)
+}
+
+// Errors is returned by ParseString when the parser recovered from one or more
+// errors. The TemplateFile returned alongside it contains the valid parts of
+// the file.
+type Errors []Error
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (e Errors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// add appends the errors that a child parser recovered from. It returns false if
+// err is an error that the child parser could not recover from.
+func (e *Errors) add(err error) bool {
+	if err == nil {
+		return true
+	}
+	if errs, ok := err.(Errors); ok {
+		*e = append(*e, errs...)
+		return true
+	}
+	return false
+}
+
+func (e Errors) orNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// newError creates an Error from an error found while parsing a node that started at
+// start. It returns the position that the error was found at.
+func newError(err error, start parse.Position) (e Error, at parse.Position) {
+	pe, isParseError := err.(parse.ParseError)
+	if !isParseError {
+		return Error{Msg: err.Error(), Pos: positionOf(start)}, start
+	}
+	at = start
+	if pe.Pos.Index > start.Index {
+		at = pe.Pos
+	}
+	return Error{Msg: pe.Msg, Pos: positionOf(pe.Pos)}, at
+}
+
+// recoverAt skips past a node that failed to parse at start, so that parsing can
+// carry on with the next node. If the error was found at the start of a line, parsing
+// carries on from there, otherwise the rest of the line is skipped. It returns false
+// if there's nothing left to skip.
+func recoverAt(pi *parse.Input, start parse.Position, err error) (e Error, ok bool) {
+	e, at := newError(err, start)
+	pi.Seek(at.Index)
+	if at.Index > start.Index && at.Col == 0 {
+		e.Range = NewRange(start, at)
+		return e, true
+	}
+	rest, _ := pi.Peek(-1)
+	if len(rest) == 0 {
+		return e, false
+	}
+	if i := strings.IndexByte(rest, '\n'); i >= 0 {
+		pi.Take(i + 1)
+	} else {
+		pi.Take(len(rest))
+	}
+	e.Range = NewRange(at, pi.Position())
+	return e, true
+}
@@ -134,6 +134,46 @@ loop:
 	return NewExpression(sb.String(), from, pi.Position()), true, nil
 }
 
+// parameters reads Go function parameters up to the closing bracket that matches the
+// opening bracket that's already been read, so that types such as func(int) string can
+// be used. The closing bracket isn't read.
+var parameters = parse.Func(func(pi *parse.Input) (s string, ok bool, err error) {
+	start := pi.Index()
+	var sb strings.Builder
+	var depth int
+	for {
+		var result string
+		if result, ok, err = string_lit.Parse(pi); err != nil {
+			return
+		}
+		if !ok {
+			if result, ok, err = rune_lit.Parse(pi); err != nil {
+				return
+			}
+		}
+		if ok {
+			sb.WriteString(result)
+			continue
+		}
+		c, ok := pi.Peek(1)
+		if !ok {
+			pi.Seek(start)
+			return "", false, nil
+		}
+		switch c {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			if depth == 0 && c == ")" {
+				return sb.String(), true, nil
+			}
+			depth--
+		}
+		pi.Take(1)
+		sb.WriteString(c)
+	}
+})
+
 // Letters and digits
 
 var octal_digit = parse.RuneIn("01234567")
@@ -5,6 +5,8 @@ import (
 )
 
 var forExpression = parse.Func(func(pi *parse.Input) (r ForExpression, ok bool, err error) {
+	start := pi.Position()
+
 	// Check the prefix first.
 	if _, ok, err = parse.String("for ").Parse(pi); err != nil || !ok {
 		return
@@ -19,6 +21,9 @@ var forExpression = parse.Func(func(pi *parse.Input) (r ForExpression, ok bool,
 		return
 	}
 	r.Expression = NewExpression(fexp, from, pi.Position())
+	if err = validateGo("for", r.Expression, "for ", " {\n}"); err != nil {
+		return r, false, err
+	}
 
 	// Eat " {".
 	if _, ok, err = Must(until, "for: unterminated expression (missing '{\n')").Parse(pi); err != nil || !ok {
@@ -26,8 +31,9 @@ var forExpression = parse.Func(func(pi *parse.Input) (r ForExpression, ok bool,
 	}
 
 	// Node contents.
+	var errs Errors
 	tnp := newTemplateNodeParser(closeBraceWithOptionalPadding, "for expression closing brace")
-	if r.Children, ok, err = Must[[]Node](tnp, "for: expected nodes, but none were found").Parse(pi); err != nil || !ok {
+	if r.Children, ok, err = Must[[]Node](tnp, "for: expected nodes, but none were found").Parse(pi); !errs.add(err) || !ok {
 		return
 	}
 
@@ -35,6 +41,7 @@ var forExpression = parse.Func(func(pi *parse.Input) (r ForExpression, ok bool,
 	if _, ok, err = Must(closeBraceWithOptionalPadding, "for: missing end (expected '}')").Parse(pi); err != nil || !ok {
 		return
 	}
+	r.Range = NewRange(start, pi.Position())
 
-	return r, true, nil
+	return r, true, errs.orNil()
 })
@@ -127,7 +127,7 @@ func TestForExpressionParser(t *testing.T) {
 			if !ok {
 				t.Fatalf("unexpected failure for input %q", tt.input)
 			}
-			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+			if diff := cmp.Diff(tt.expected, actual, ignoreNodeRanges); diff != "" {
 				t.Error(diff)
 			}
 		})
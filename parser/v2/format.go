@@ -0,0 +1,181 @@
+package parser
+
+import (
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// FormatOptions control how templates are formatted.
+type FormatOptions struct {
+	// UseSpaces indents with spaces instead of tabs.
+	UseSpaces bool
+	// TabWidth is the number of columns used for a tab when calculating line widths, and
+	// the number of spaces used for each level of indentation if UseSpaces is set.
+	TabWidth int
+	// MaxLineWidth is the width at which the attributes of an element are written one per
+	// line, and text is wrapped if WrapText is set. Zero disables wrapping.
+	MaxLineWidth int
+	// MaxAttributesPerLine is the number of attributes an element can have before its
+	// attributes are written one per line. Zero disables the limit.
+	MaxAttributesPerLine int
+	// WrapText wraps text content that would go past MaxLineWidth.
+	WrapText bool
+}
+
+// DefaultFormatOptions are used by Write.
+func DefaultFormatOptions() FormatOptions {
+	return FormatOptions{
+		TabWidth: 4,
+	}
+}
+
+func (opts FormatOptions) indent() string {
+	if opts.UseSpaces {
+		return strings.Repeat(" ", opts.TabWidth)
+	}
+	return "\t"
+}
+
+// WriteWithOptions writes the formatted template file.
+func (tf TemplateFile) WriteWithOptions(w io.Writer, opts FormatOptions) error {
+	if opts.TabWidth <= 0 {
+		opts.TabWidth = DefaultFormatOptions().TabWidth
+	}
+	return tf.Write(&formatWriter{
+		w:           w,
+		opts:        opts,
+		atLineStart: true,
+	})
+}
+
+// formatWriter tracks the position within the current line as the template is written,
+// so that elements and text can be wrapped.
+type formatWriter struct {
+	w    io.Writer
+	opts FormatOptions
+	// col is the current column, with tabs counted as TabWidth columns.
+	col int
+	// lineIndent is the leading whitespace of the current line.
+	lineIndent  strings.Builder
+	atLineStart bool
+	// preDepth is greater than zero within elements where whitespace is significant.
+	preDepth int
+}
+
+func (fw *formatWriter) Write(p []byte) (n int, err error) {
+	for _, b := range p {
+		switch {
+		case b == '\n':
+			fw.col = 0
+			fw.lineIndent.Reset()
+			fw.atLineStart = true
+			continue
+		case b == '\t':
+			fw.col += fw.opts.TabWidth
+		case !utf8.RuneStart(b):
+			// Continuation bytes of multi-byte characters don't take up a column.
+		default:
+			fw.col++
+		}
+		if fw.atLineStart {
+			if b == ' ' || b == '\t' {
+				fw.lineIndent.WriteByte(b)
+				continue
+			}
+			fw.atLineStart = false
+		}
+	}
+	return fw.w.Write(p)
+}
+
+// indentString returns the indentation for the level, using the options of the writer if
+// it has any.
+func indentString(w io.Writer, level int) string {
+	if fw, ok := w.(*formatWriter); ok {
+		return strings.Repeat(fw.opts.indent(), level)
+	}
+	return strings.Repeat("\t", level)
+}
+
+// shouldWrapAttributes returns true if the attributes of the element should be written one
+// per line. It's called after the start of the opening tag has been written.
+func shouldWrapAttributes(w io.Writer, e Element) bool {
+	fw, ok := w.(*formatWriter)
+	if !ok || len(e.Attributes) == 0 {
+		return false
+	}
+	if fw.opts.MaxAttributesPerLine > 0 && len(e.Attributes) > fw.opts.MaxAttributesPerLine {
+		return true
+	}
+	if fw.opts.MaxLineWidth <= 0 {
+		return false
+	}
+	width := fw.col + len(">")
+	for _, a := range e.Attributes {
+		if a.IsMultilineAttr() {
+			// Conditional attributes are already written on their own lines.
+			continue
+		}
+		sb := new(strings.Builder)
+		if err := a.Write(sb, 0); err != nil {
+			return false
+		}
+		width += 1 + utf8.RuneCountInString(sb.String())
+	}
+	return width > fw.opts.MaxLineWidth
+}
+
+// shouldWrapText returns true if text should be wrapped at the maximum line width.
+func shouldWrapText(w io.Writer) (fw *formatWriter, ok bool) {
+	fw, ok = w.(*formatWriter)
+	if !ok {
+		return nil, false
+	}
+	return fw, fw.opts.WrapText && fw.opts.MaxLineWidth > 0 && fw.preDepth == 0
+}
+
+// writeWrappedText writes text, replacing spaces with new lines where the text would go past
+// the maximum line width. Runs of whitespace are collapsed, since browsers don't display them.
+func (fw *formatWriter) writeWrappedText(indent int, s string) error {
+	words := strings.Fields(s)
+	if len(words) == 0 {
+		return writeIndent(fw, indent, s)
+	}
+	// Text that starts a line is continued at the same indentation, but text that follows
+	// other content is continued at the next level.
+	startsLine := fw.atLineStart
+	if err := writeIndent(fw, indent, ""); err != nil {
+		return err
+	}
+	continuation := fw.lineIndent.String()
+	if !startsLine {
+		continuation += fw.opts.indent()
+	}
+	if strings.IndexFunc(s[:1], unicode.IsSpace) == 0 {
+		if _, err := io.WriteString(fw, " "); err != nil {
+			return err
+		}
+	}
+	for i, word := range words {
+		if i > 0 {
+			separator := " "
+			if fw.col+1+utf8.RuneCountInString(word) > fw.opts.MaxLineWidth {
+				separator = "\n" + continuation
+			}
+			if _, err := io.WriteString(fw, separator); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(fw, word); err != nil {
+			return err
+		}
+	}
+	if last, _ := utf8.DecodeLastRuneInString(s); unicode.IsSpace(last) {
+		if _, err := io.WriteString(fw, " "); err != nil {
+			return err
+		}
+	}
+	return nil
+}
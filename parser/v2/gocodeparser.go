@@ -0,0 +1,46 @@
+package parser
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/a-h/parse"
+)
+
+// {{ total := sum(items) }}
+var goCode = parse.Func(func(pi *parse.Input) (r GoCode, ok bool, err error) {
+	from := pi.Position()
+
+	// Check the prefix first.
+	if _, ok, err = parse.String("{{").Parse(pi); err != nil || !ok {
+		return
+	}
+	if _, ok, err = parse.OptionalWhitespace.Parse(pi); err != nil || !ok {
+		return
+	}
+
+	// Once we have a prefix, we must have Go statements.
+	if r.Expression, ok, err = exp.Parse(pi); err != nil || !ok {
+		return
+	}
+	// Drop the trailing whitespace before the closing braces.
+	if trimmed := strings.TrimRightFunc(r.Expression.Value, unicode.IsSpace); len(trimmed) < len(r.Expression.Value) {
+		r.Expression = NewExpression(trimmed, positionWithin(r.Expression, 0), positionWithin(r.Expression, len(trimmed)))
+	}
+	if err = validateGo("go code", r.Expression, "", ""); err != nil {
+		return r, false, err
+	}
+
+	// }}
+	if _, ok, err = Must(parse.All(parse.OptionalWhitespace, parse.String("}}")), "go code: missing close braces (}})").Parse(pi); err != nil || !ok {
+		return
+	}
+	r.Range = NewRange(from, pi.Position())
+
+	// Go code doesn't render anything, so the whitespace that follows it isn't rendered either.
+	if _, _, err = parse.OptionalWhitespace.Parse(pi); err != nil {
+		return
+	}
+
+	return r, true, nil
+})
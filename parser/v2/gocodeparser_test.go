@@ -0,0 +1,118 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/a-h/parse"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGoCodeParser(t *testing.T) {
+	var tests = []struct {
+		name     string
+		input    string
+		expected GoCode
+	}{
+		{
+			name:  "single statement",
+			input: `{{ total := sum(items) }}`,
+			expected: GoCode{
+				Expression: Expression{
+					Value: `total := sum(items)`,
+					Range: Range{
+						From: Position{
+							Index: 3,
+							Line:  0,
+							Col:   3,
+						},
+						To: Position{
+							Index: 22,
+							Line:  0,
+							Col:   22,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "multiple lines, including braces",
+			input: `{{
+	total := 0
+	for _, item := range items {
+		total += item
+	}
+}}`,
+			expected: GoCode{
+				Expression: Expression{
+					Value: "total := 0\n\tfor _, item := range items {\n\t\ttotal += item\n\t}",
+					Range: Range{
+						From: Position{
+							Index: 4,
+							Line:  1,
+							Col:   1,
+						},
+						To: Position{
+							Index: 63,
+							Line:  4,
+							Col:   2,
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			input := parse.NewInput(tt.input)
+			actual, ok, err := goCode.Parse(input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !ok {
+				t.Fatalf("unexpected failure for input %q", tt.input)
+			}
+			if diff := cmp.Diff(tt.expected, actual, ignoreNodeRanges); diff != "" {
+				t.Error(diff)
+			}
+
+			// Check the index.
+			cut := tt.input[actual.Expression.Range.From.Index:actual.Expression.Range.To.Index]
+			if tt.expected.Expression.Value != cut {
+				t.Errorf("range, expected %q, got %q", tt.expected.Expression.Value, cut)
+			}
+		})
+	}
+}
+
+func TestGoCodeParserErrors(t *testing.T) {
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "invalid Go",
+			input:    `{{ total := }}`,
+			expected: "go code: expected operand, found '}': line 0, col 11",
+		},
+		{
+			name:     "unterminated",
+			input:    `{{ total := 1 }`,
+			expected: "go code: missing close braces (}})",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := goCode.Parse(parse.NewInput(tt.input))
+			if err == nil {
+				t.Fatal("expected an error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("expected error %q, got %q", tt.expected, err.Error())
+			}
+		})
+	}
+}
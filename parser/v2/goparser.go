@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	goparser "go/parser"
+	"go/scanner"
+	"go/token"
+	"strings"
+
+	"github.com/a-h/parse"
+)
+
+// Go code in templates is checked with go/parser, so that syntax errors are reported
+// at their position in the template, rather than as errors in the generated code.
+
+const goFuncHeader = "package p\nfunc _() {\n"
+
+// validateGo checks that the expression is valid Go when it's placed between prefix
+// and suffix in the body of a function, e.g. between "if " and " {}". If it isn't,
+// it returns a parse error at the position of the problem within the expression.
+func validateGo(name string, e Expression, prefix, suffix string) error {
+	src := goFuncHeader + prefix + e.Value + suffix + "\n}\n"
+	_, err := goparser.ParseFile(token.NewFileSet(), "", src, 0)
+	return goParseError(name, e, err, len(goFuncHeader)+len(prefix))
+}
+
+// validateGoSignature checks that the expression is a valid Go function signature, with
+// an optional receiver and type parameters, e.g. List[T any](items []T).
+func validateGoSignature(name string, e Expression) error {
+	const prefix = "package p\nfunc "
+	_, err := goparser.ParseFile(token.NewFileSet(), "", prefix+e.Value+" {}\n", 0)
+	return goParseError(name, e, err, len(prefix))
+}
+
+// validateGoExpression checks that the expression is a valid Go expression.
+func validateGoExpression(name string, e Expression) error {
+	_, err := goparser.ParseExprFrom(token.NewFileSet(), "", e.Value, 0)
+	return goParseError(name, e, err, 0)
+}
+
+// validateExpressionAttribute checks the expression of an attribute. The expression of a
+// class attribute is a list of classes, which is placed in a []any literal.
+func validateExpressionAttribute(attr ExpressionAttribute) error {
+	name := fmt.Sprintf("attribute %q", attr.Name)
+	if attr.Name == "class" {
+		return validateGo(name, attr.Expression, "_ = []any{", "}")
+	}
+	return validateGoExpression(name, attr.Expression)
+}
+
+// goParseError converts the first error returned by go/parser to a parse error at
+// its position within the expression, where start is the offset of the expression
+// within the Go source that was parsed.
+func goParseError(name string, e Expression, err error, start int) error {
+	if err == nil {
+		return nil
+	}
+	var errs scanner.ErrorList
+	if !errors.As(err, &errs) || len(errs) == 0 {
+		return err
+	}
+	offset := errs[0].Pos.Offset - start
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > len(e.Value) {
+		offset = len(e.Value)
+	}
+	return parse.Error(fmt.Sprintf("%s: %s", name, errs[0].Msg), positionWithin(e, offset))
+}
+
+// positionWithin returns the position of the byte at offset within the expression.
+func positionWithin(e Expression, offset int) parse.Position {
+	pos := parse.Position{
+		Index: int(e.Range.From.Index) + offset,
+		Line:  int(e.Range.From.Line),
+		Col:   int(e.Range.From.Col) + offset,
+	}
+	if lines := strings.Count(e.Value[:offset], "\n"); lines > 0 {
+		pos.Line += lines
+		pos.Col = offset - strings.LastIndex(e.Value[:offset], "\n") - 1
+	}
+	return pos
+}
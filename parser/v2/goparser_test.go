@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/a-h/parse"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGoValidation(t *testing.T) {
+	var tests = []struct {
+		name     string
+		input    string
+		expected error
+	}{
+		{
+			name:  "string expression: unbalanced parenthesis",
+			input: `{ strings.ToUpper("a" }`,
+			expected: parse.Error("string expression: missing ',' before newline in argument list",
+				parse.Position{Index: 21, Line: 0, Col: 21}),
+		},
+		{
+			name: "if: assignment instead of comparison",
+			input: `if x = 1 {
+	<br/>
+}`,
+			expected: parse.Error("if: expected boolean expression, found assignment (missing parentheses around composite literal?)",
+				parse.Position{Index: 3, Line: 0, Col: 3}),
+		},
+		{
+			name: "for: missing range expression",
+			input: `for _, x := range {
+	<br/>
+}`,
+			expected: parse.Error("for: expected operand, found '{'",
+				parse.Position{Index: 17, Line: 0, Col: 17}),
+		},
+		{
+			name: "switch: invalid expression",
+			input: `switch x. {
+	case 1:
+		<br/>
+}`,
+			expected: parse.Error("switch: expected selector or type assertion, found '{'",
+				parse.Position{Index: 9, Line: 0, Col: 9}),
+		},
+		{
+			name: "case: invalid expression",
+			input: `switch x {
+	case 1 +:
+		<br/>
+}`,
+			expected: parse.Error("case: expected operand, found ':'",
+				parse.Position{Index: 20, Line: 1, Col: 9}),
+		},
+		{
+			name:  "templ element: unbalanced parenthesis",
+			input: "@Button(\"a\"\n",
+			expected: parse.Error("templ element: missing ',' before newline in argument list",
+				parse.Position{Index: 11, Line: 0, Col: 11}),
+		},
+		{
+			name:  "call template expression: invalid expression",
+			input: `{! Button(1 2) }`,
+			expected: parse.Error("call template expression: missing ',' in argument list",
+				parse.Position{Index: 12, Line: 0, Col: 12}),
+		},
+		{
+			name:  "attribute: class attributes can contain a list of classes",
+			input: `<a class={ "a", templ.KV("b", true) }></a>`,
+		},
+		{
+			name:  "attribute: multiline expression",
+			input: "<a href={ url(\n1,, 2) }></a>",
+			expected: parse.Error(`attribute "href": expected operand, found ','`,
+				parse.Position{Index: 17, Line: 1, Col: 2}),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := parseTemplateNode(parse.NewInput(tt.input))
+			if diff := cmp.Diff(tt.expected, err); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
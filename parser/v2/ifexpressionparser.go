@@ -9,6 +9,8 @@ var ifExpression ifExpressionParser
 type ifExpressionParser struct{}
 
 func (ifExpressionParser) Parse(pi *parse.Input) (r IfExpression, ok bool, err error) {
+	from := pi.Position()
+
 	// Check the prefix first.
 	if _, ok, err = parse.String("if ").Parse(pi); err != nil || !ok {
 		return
@@ -19,6 +21,9 @@ func (ifExpressionParser) Parse(pi *parse.Input) (r IfExpression, ok bool, err e
 	if r.Expression, ok, err = Must(ExpressionOf(parse.StringUntil(parse.All(openBraceWithOptionalPadding, parse.NewLine))), "if: unterminated (missing closing '{\n')").Parse(pi); err != nil || !ok {
 		return
 	}
+	if err = validateGo("if", r.Expression, "if ", " {\n}"); err != nil {
+		return r, false, err
+	}
 
 	// Eat " {\n".
 	if _, ok, err = Must(parse.All(openBraceWithOptionalPadding, parse.NewLine), "if: unterminated (missing closing '{')").Parse(pi); err != nil || !ok {
@@ -29,13 +34,26 @@ func (ifExpressionParser) Parse(pi *parse.Input) (r IfExpression, ok bool, err e
 
 	// Read the 'Then' nodes.
 	// If there's no match, there's a problem in the template nodes.
+	var errs Errors
 	np := newTemplateNodeParser(parse.Any(StripType(elseExpression), StripType(closeBraceWithOptionalPadding)), "else expression or closing brace")
-	if r.Then, ok, err = Must[[]Node](np, "if: expected nodes, but none were found").Parse(pi); err != nil || !ok {
+	if r.Then, ok, err = Must[[]Node](np, "if: expected nodes, but none were found").Parse(pi); !errs.add(err) || !ok {
 		return
 	}
 
+	// Read the optional 'ElseIf' Nodes.
+	for {
+		var elseIf ElseIfExpression
+		if elseIf, ok, err = elseIfExpression.Parse(pi); !errs.add(err) {
+			return
+		}
+		if !ok {
+			break
+		}
+		r.ElseIfs = append(r.ElseIfs, elseIf)
+	}
+
 	// Read the optional 'Else' Nodes.
-	if r.Else, _, err = elseExpression.Parse(pi); err != nil {
+	if r.Else, _, err = elseExpression.Parse(pi); !errs.add(err) {
 		return
 	}
 
@@ -43,8 +61,58 @@ func (ifExpressionParser) Parse(pi *parse.Input) (r IfExpression, ok bool, err e
 	if _, ok, err = Must(closeBraceWithOptionalPadding, "if: missing end (expected '}')").Parse(pi); err != nil || !ok {
 		return
 	}
+	r.Range = NewRange(from, pi.Position())
+
+	return r, true, errs.orNil()
+}
+
+// The start of an else if expression, after the closing brace of the previous block.
+var elseIfPrefixParser = parse.All(
+	parse.String("else"),
+	parse.Whitespace,
+	parse.String("if"),
+	parse.Whitespace)
+
+var elseIfExpression parse.Parser[ElseIfExpression] = elseIfExpressionParser{}
+
+type elseIfExpressionParser struct{}
+
+func (elseIfExpressionParser) Parse(pi *parse.Input) (r ElseIfExpression, ok bool, err error) {
+	start := pi.Index()
+
+	// } else if
+	if _, ok, err = parse.All(parse.Rune('}'), parse.OptionalWhitespace).Parse(pi); err != nil || !ok {
+		pi.Seek(start)
+		return
+	}
+	from := pi.Position()
+	if _, ok, err = elseIfPrefixParser.Parse(pi); err != nil || !ok {
+		pi.Seek(start)
+		return
+	}
+
+	// Once we've got a prefix, read until {\n.
+	if r.Expression, ok, err = Must(ExpressionOf(parse.StringUntil(parse.All(openBraceWithOptionalPadding, parse.NewLine))), "else if: unterminated (missing closing '{\n')").Parse(pi); err != nil || !ok {
+		return
+	}
+	if err = validateGo("else if", r.Expression, "if ", " {\n}"); err != nil {
+		return r, false, err
+	}
+
+	// Eat " {\n".
+	if _, ok, err = Must(parse.All(openBraceWithOptionalPadding, parse.NewLine), "else if: unterminated (missing closing '{')").Parse(pi); err != nil || !ok {
+		return
+	}
+
+	// Read the 'Then' nodes, up to the next else if, else, or closing brace.
+	var errs Errors
+	np := newTemplateNodeParser(closeBraceWithOptionalPadding, "else expression or closing brace")
+	if r.Then, ok, err = Must[[]Node](np, "else if: expected nodes, but none were found").Parse(pi); !errs.add(err) || !ok {
+		return
+	}
+	r.Range = NewRange(from, pi.Position())
 
-	return r, true, nil
+	return r, true, errs.orNil()
 }
 
 var endElseParser = parse.All(
@@ -69,10 +137,11 @@ func (elseExpressionParser) Parse(in *parse.Input) (r []Node, ok bool, err error
 	}
 
 	// Else contents
-	if r, ok, err = newTemplateNodeParser(closeBraceWithOptionalPadding, "else expression closing brace").Parse(in); err != nil || !ok {
+	var errs Errors
+	if r, ok, err = newTemplateNodeParser(closeBraceWithOptionalPadding, "else expression closing brace").Parse(in); !errs.add(err) || !ok {
 		in.Seek(start)
 		return
 	}
 
-	return r, true, nil
+	return r, true, errs.orNil()
 }
@@ -10,6 +10,8 @@ var expressionFuncEnd = parse.All(parse.Rune(')'), openBraceWithOptionalPadding)
 // Template
 
 var template = parse.Func(func(pi *parse.Input) (r HTMLTemplate, ok bool, err error) {
+	from := pi.Position()
+
 	// templ FuncName(p Person, other Other) {
 	var te templateExpression
 	if te, ok, err = templateExpressionParser.Parse(pi); err != nil || !ok {
@@ -19,8 +21,9 @@ var template = parse.Func(func(pi *parse.Input) (r HTMLTemplate, ok bool, err er
 
 	// Once we're in a template, we should expect some template whitespace, if/switch/for,
 	// or node string expressions etc.
+	var errs Errors
 	r.Children, ok, err = Must[[]Node](newTemplateNodeParser(closeBraceWithOptionalPadding, "template closing brace"), "templ: expected nodes in templ body, but found none").Parse(pi)
-	if err != nil || !ok {
+	if !errs.add(err) || !ok {
 		return
 	}
 
@@ -35,6 +38,7 @@ var template = parse.Func(func(pi *parse.Input) (r HTMLTemplate, ok bool, err er
 	if err != nil {
 		return
 	}
+	r.Range = NewRange(from, pi.Position())
 
-	return r, true, nil
+	return r, true, errs.orNil()
 })
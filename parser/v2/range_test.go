@@ -0,0 +1,141 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/a-h/parse"
+	"github.com/google/go-cmp/cmp"
+)
+
+// ignoreNodeRanges ignores the ranges of nodes and attributes, so that parser tests can
+// check the structure of the output. The ranges are checked by TestNodeRanges.
+var ignoreNodeRanges = cmp.FilterPath(func(p cmp.Path) bool {
+	sf, ok := p.Last().(cmp.StructField)
+	if !ok || sf.Name() != "Range" {
+		return false
+	}
+	parent := p.Index(-2).Type()
+	return parent != reflect.TypeOf(Expression{}) && parent != reflect.TypeOf(Error{})
+}, cmp.Ignore())
+
+func TestNodeRanges(t *testing.T) {
+	input := `templ Name(items []string) {
+	<!DOCTYPE html>
+	<!-- comment -->
+	<div class="a" disabled href={ url } checked?={ ok }>
+		Text
+		{ "string" }
+		{! Call() }
+		@Other()
+		{ children... }
+	</div>
+	if ok {
+		<br/>
+	}
+	for _, item := range items {
+		<script>var x = 1;</script>
+	}
+	switch x {
+		case 1:
+			1
+	}
+}`
+	tf, err := ParseString(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	source := func(r Range) string {
+		return input[r.From.Index:r.To.Index]
+	}
+	template := tf.Nodes[0].(HTMLTemplate)
+	div := template.Children[5].(Element)
+	ifNode := template.Children[7].(IfExpression)
+	forNode := template.Children[9].(ForExpression)
+	switchNode := template.Children[11].(SwitchExpression)
+	actual := []string{
+		source(template.Range),
+		source(template.Children[0].(Whitespace).Range),
+		source(template.Children[1].(DocType).Range),
+		source(template.Children[3].(Comment).Range),
+		source(div.Range),
+		source(div.Attributes[0].(ConstantAttribute).Range),
+		source(div.Attributes[1].(BoolConstantAttribute).Range),
+		source(div.Attributes[2].(ExpressionAttribute).Range),
+		source(div.Attributes[3].(BoolExpressionAttribute).Range),
+		source(div.Children[1].(Text).Range),
+		source(div.Children[3].(StringExpression).Range),
+		source(div.Children[5].(CallTemplateExpression).Range),
+		source(div.Children[7].(TemplElementExpression).Range),
+		source(div.Children[8].(Whitespace).Range),
+		source(div.Children[9].(ChildrenExpression).Range),
+		source(ifNode.Range),
+		source(ifNode.Then[1].(Element).Range),
+		source(forNode.Range),
+		source(forNode.Children[1].(RawElement).Range),
+		source(switchNode.Range),
+		source(switchNode.Cases[0].Range),
+	}
+	expected := []string{
+		input,
+		"\t",
+		"<!DOCTYPE html>",
+		"<!-- comment -->",
+		`<div class="a" disabled href={ url } checked?={ ok }>
+		Text
+		{ "string" }
+		{! Call() }
+		@Other()
+		{ children... }
+	</div>`,
+		`class="a"`,
+		"disabled",
+		"href={ url }",
+		"checked?={ ok }",
+		"Text",
+		`{ "string" }`,
+		"{! Call() }",
+		"@Other()",
+		"\n\t\t",
+		"{ children... }",
+		"if ok {\n\t\t<br/>\n\t}",
+		"<br/>",
+		"for _, item := range items {\n\t\t<script>var x = 1;</script>\n\t}",
+		"<script>var x = 1;</script>",
+		"switch x {\n\t\tcase 1:\n\t\t\t1\n\t}",
+		"case 1:\n\t\t\t1\n\t",
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff(NewPosition(65, 3, 1), div.Range.From); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestCSSRanges(t *testing.T) {
+	input := `css Name() {
+	color: #ffffff;
+	background-color: { constants.BackgroundColor };
+}`
+	css, ok, err := cssParser.Parse(parse.NewInput(input))
+	if err != nil || !ok {
+		t.Fatalf("failed to parse css: %v", err)
+	}
+	source := func(r Range) string {
+		return input[r.From.Index:r.To.Index]
+	}
+	actual := []string{
+		source(css.Range),
+		source(css.Properties[0].(ConstantCSSProperty).Range),
+		source(css.Properties[1].(ExpressionCSSProperty).Range),
+	}
+	expected := []string{
+		input,
+		"color: #ffffff;",
+		"background-color: { constants.BackgroundColor };",
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -20,6 +20,7 @@ type rawElementParser struct {
 
 func (p rawElementParser) Parse(pi *parse.Input) (e RawElement, ok bool, err error) {
 	start := pi.Index()
+	from := pi.Position()
 
 	// <
 	if _, ok, err = lt.Parse(pi); err != nil || !ok {
@@ -57,6 +58,7 @@ func (p rawElementParser) Parse(pi *parse.Input) (e RawElement, ok bool, err err
 	}
 	// Cut the end element.
 	_, _, _ = end.Parse(pi)
+	e.Range = NewRange(from, pi.Position())
 
 	return e, true, nil
 }
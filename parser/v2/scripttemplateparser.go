@@ -6,6 +6,7 @@ import (
 
 var scriptTemplateParser = parse.Func(func(pi *parse.Input) (r ScriptTemplate, ok bool, err error) {
 	start := pi.Index()
+	from := pi.Position()
 
 	// Parse the name.
 	var se scriptExpression
@@ -29,6 +30,7 @@ var scriptTemplateParser = parse.Func(func(pi *parse.Input) (r ScriptTemplate, o
 		pi.Seek(start)
 		return
 	}
+	r.Range = NewRange(from, pi.Position())
 
 	return r, true, nil
 })
@@ -5,6 +5,8 @@ import (
 )
 
 var stringExpression = parse.Func(func(pi *parse.Input) (r StringExpression, ok bool, err error) {
+	from := pi.Position()
+
 	// Check the prefix first.
 	if _, ok, err = parse.Or(parse.String("{ "), parse.String("{")).Parse(pi); err != nil || !ok {
 		return
@@ -14,11 +16,15 @@ var stringExpression = parse.Func(func(pi *parse.Input) (r StringExpression, ok
 	if r.Expression, ok, err = exp.Parse(pi); err != nil || !ok {
 		return
 	}
+	if err = validateGoExpression("string expression", r.Expression); err != nil {
+		return r, false, err
+	}
 
 	// }
 	if _, ok, err = Must(closeBraceWithOptionalPadding, "string expression: missing close brace").Parse(pi); err != nil || !ok {
 		return
 	}
+	r.Range = NewRange(from, pi.Position())
 
 	return r, true, nil
 })
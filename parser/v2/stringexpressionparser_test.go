@@ -69,7 +69,7 @@ func TestStringExpressionParser(t *testing.T) {
 			if !ok {
 				t.Fatalf("unexpected failure for input %q", tt.input)
 			}
-			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+			if diff := cmp.Diff(tt.expected, actual, ignoreNodeRanges); diff != "" {
 				t.Error(diff)
 			}
 
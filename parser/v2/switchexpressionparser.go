@@ -7,6 +7,8 @@ import (
 )
 
 var switchExpression = parse.Func(func(pi *parse.Input) (r SwitchExpression, ok bool, err error) {
+	from := pi.Position()
+
 	// Check the prefix first.
 	if _, ok, err = parse.String("switch ").Parse(pi); err != nil || !ok {
 		return
@@ -17,6 +19,9 @@ var switchExpression = parse.Func(func(pi *parse.Input) (r SwitchExpression, ok
 	if r.Expression, ok, err = Must(endOfStatementExpression, "switch: unterminated (missing closing '{\n')").Parse(pi); err != nil || !ok {
 		return
 	}
+	if err = validateGo("switch", r.Expression, "switch ", " {\n}"); err != nil {
+		return r, false, err
+	}
 
 	// Eat " {\n".
 	if _, ok, err = Must(parse.All(openBraceWithOptionalPadding, parse.NewLine), "switch: unterminated (missing closing '{\n')").Parse(pi); err != nil || !ok {
@@ -26,10 +31,11 @@ var switchExpression = parse.Func(func(pi *parse.Input) (r SwitchExpression, ok
 	// Once we've had the start of a switch block, we must conclude the block.
 
 	// Read the optional 'case' nodes.
+	var errs Errors
 	for {
 		var ce CaseExpression
 		ce, ok, err = caseExpressionParser.Parse(pi)
-		if err != nil {
+		if !errs.add(err) {
 			return
 		}
 		if !ok {
@@ -42,8 +48,9 @@ var switchExpression = parse.Func(func(pi *parse.Input) (r SwitchExpression, ok
 	if _, ok, err = Must(closeBraceWithOptionalPadding, "switch: missing end (expected '}')").Parse(pi); err != nil || !ok {
 		return
 	}
+	r.Range = NewRange(from, pi.Position())
 
-	return r, true, nil
+	return r, true, errs.orNil()
 })
 
 var caseExpressionStartParser = parse.Func(func(in *parse.Input) (e Expression, ok bool, err error) {
@@ -77,17 +84,22 @@ var caseExpressionParser = parse.Func(func(pi *parse.Input) (r CaseExpression, o
 	if r.Expression, ok, err = caseExpressionStartParser.Parse(pi); err != nil || !ok {
 		return
 	}
+	if err = validateGo("case", r.Expression, "switch {\n", "\n}"); err != nil {
+		return r, false, err
+	}
 
 	// Read until the next case statement, default, or end of the block.
+	var errs Errors
 	pr := newTemplateNodeParser(parse.Any(StripType(closeBraceWithOptionalPadding), StripType(caseExpressionStartParser)), "closing brace or case expression")
-	if r.Children, ok, err = Must[[]Node](pr, "case: expected nodes, but none were found").Parse(pi); err != nil || !ok {
+	if r.Children, ok, err = Must[[]Node](pr, "case: expected nodes, but none were found").Parse(pi); !errs.add(err) || !ok {
 		return
 	}
+	r.Range = Range{From: r.Expression.Range.From, To: positionOf(pi.Position())}
 
 	// Optional whitespace.
 	if _, ok, err = parse.OptionalWhitespace.Parse(pi); err != nil || !ok {
 		return
 	}
 
-	return r, true, nil
+	return r, true, errs.orNil()
 })
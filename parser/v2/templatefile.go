@@ -42,6 +42,9 @@ func isGoIdentifier(s string) bool {
 	return true
 }
 
+// ParseString parses a template file. If the parser recovered from errors in the
+// file, the returned error is Errors, and the TemplateFile holds the valid parts
+// of the file.
 func ParseString(template string) (TemplateFile, error) {
 	tf, ok, err := NewTemplateFileParser("main").Parse(parse.NewInput(template))
 	if err != nil {
@@ -93,14 +96,17 @@ func (p TemplateFileParser) Parse(pi *parse.Input) (tf TemplateFile, ok bool, er
 	// Optional whitespace.
 	_, _, _ = parse.OptionalWhitespace.Parse(pi)
 
+	var errs Errors
 outer:
 	for {
 		// Optional templates, CSS, and script templates.
 		// templ Name(p Parameter)
+		start := pi.Position()
 		var tn HTMLTemplate
 		tn, ok, err = template.Parse(pi)
-		if err != nil {
-			return tf, false, err
+		if !errs.add(err) {
+			errs = append(errs, skipTemplate(pi, start, err))
+			continue
 		}
 		if ok {
 			tf.Nodes = append(tf.Nodes, tn)
@@ -112,7 +118,8 @@ outer:
 		var cn CSSTemplate
 		cn, ok, err = cssParser.Parse(pi)
 		if err != nil {
-			return tf, false, err
+			errs = append(errs, skipTemplate(pi, start, err))
+			continue
 		}
 		if ok {
 			tf.Nodes = append(tf.Nodes, cn)
@@ -124,7 +131,8 @@ outer:
 		var sn ScriptTemplate
 		sn, ok, err = scriptTemplateParser.Parse(pi)
 		if err != nil {
-			return tf, false, err
+			errs = append(errs, skipTemplate(pi, start, err))
+			continue
 		}
 		if ok {
 			tf.Nodes = append(tf.Nodes, sn)
@@ -143,8 +151,7 @@ outer:
 			if l, ok, err = parse.StringUntil(parse.Or(parse.NewLine, parse.EOF[string]())).Parse(pi); err != nil {
 				return
 			}
-			hasTemplatePrefix := strings.HasPrefix(l, "templ ") || strings.HasPrefix(l, "css ") || strings.HasPrefix(l, "script ")
-			if hasTemplatePrefix && strings.HasSuffix(l, "{") {
+			if isTemplateStart(l) {
 				// Unread the line.
 				pi.Seek(last)
 				// Take the code so far.
@@ -174,5 +181,31 @@ outer:
 		}
 	}
 
-	return tf, true, nil
+	return tf, true, errs.orNil()
+}
+
+func isTemplateStart(line string) bool {
+	hasTemplatePrefix := strings.HasPrefix(line, "templ ") || strings.HasPrefix(line, "css ") || strings.HasPrefix(line, "script ")
+	return hasTemplatePrefix && strings.HasSuffix(line, "{")
+}
+
+// skipTemplate skips a template that failed to parse, up to the start of the next
+// template, or the end of the file, and returns the error with the range that was skipped.
+func skipTemplate(pi *parse.Input, start parse.Position, err error) (e Error) {
+	e, _ = newError(err, start)
+	// Skip the first line of the template, then every line up to the next template.
+	pi.Seek(start.Index)
+	for first := true; ; first = false {
+		last := pi.Index()
+		l, _, _ := parse.StringUntil(parse.Or(parse.NewLine, parse.EOF[string]())).Parse(pi)
+		if !first && isTemplateStart(l) {
+			pi.Seek(last)
+			break
+		}
+		if _, ok, _ := parse.NewLine.Parse(pi); !ok {
+			break
+		}
+	}
+	e.Range = NewRange(start, pi.Position())
+	return e
 }
@@ -3,6 +3,8 @@ package parser
 import (
 	"reflect"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestTemplateFileParser(t *testing.T) {
@@ -157,3 +159,77 @@ func TestDefaultPackageName(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplateFileParserErrorRecovery(t *testing.T) {
+	t.Run("element errors skip the rest of the line, and keep the rest of the template", func(t *testing.T) {
+		input := `package main
+
+templ A() {
+	<div>
+		<a></b>
+		<span>ok</span>
+	</div>
+}
+
+templ B() {
+	<p>
+}
+`
+		tf, err := ParseString(input)
+		expected := Errors{
+			{
+				Msg:   "<a>: mismatched end tag, expected '</a>', got '</b>'",
+				Pos:   NewPosition(38, 4, 5),
+				Range: Range{From: NewPosition(38, 4, 5), To: NewPosition(43, 5, 0)},
+			},
+			{
+				Msg:   "<p>: expected end tag not present or invalid tag contents",
+				Pos:   NewPosition(89, 11, 0),
+				Range: Range{From: NewPosition(85, 10, 1), To: NewPosition(89, 11, 0)},
+			},
+		}
+		if diff := cmp.Diff(expected, err); diff != "" {
+			t.Fatal(diff)
+		}
+		if len(tf.Nodes) != 2 {
+			t.Fatalf("expected 2 templates, got %d nodes", len(tf.Nodes))
+		}
+		div := tf.Nodes[0].(HTMLTemplate).Children[1].(Element)
+		var names []string
+		for _, n := range div.Children {
+			if e, isElement := n.(Element); isElement {
+				names = append(names, e.Name)
+			}
+		}
+		if diff := cmp.Diff([]string{"span"}, names); diff != "" {
+			t.Error(diff)
+		}
+	})
+	t.Run("template errors skip to the next template", func(t *testing.T) {
+		input := `templ A() {
+	</div>
+}
+
+templ B() {
+	<span>ok</span>
+}
+`
+		tf, err := ParseString(input)
+		expected := Errors{
+			{
+				Msg:   "template closing brace not found",
+				Pos:   NewPosition(13, 1, 1),
+				Range: Range{From: NewPosition(0, 0, 0), To: NewPosition(23, 4, 0)},
+			},
+		}
+		if diff := cmp.Diff(expected, err); diff != "" {
+			t.Fatal(diff)
+		}
+		if len(tf.Nodes) != 1 {
+			t.Fatalf("expected 1 template, got %d nodes", len(tf.Nodes))
+		}
+		if name := tf.Nodes[0].(HTMLTemplate).Expression.Value; name != "B()" {
+			t.Errorf("expected template B(), got %q", name)
+		}
+	})
+}
@@ -12,6 +12,7 @@ import (
 // templ Func(p Parameter) {
 // templ (data Data) Func(p Parameter) {
 // templ (data []string) Func(p Parameter) {
+// templ List[T any](items []T) {
 type templateExpression struct {
 	Expression Expression
 }
@@ -36,6 +37,9 @@ var templateExpressionParser = parse.Func(func(pi *parse.Input) (r templateExpre
 	if r.Expression, ok, err = Must(ExpressionOf(parse.StringUntil(until)), msg).Parse(pi); err != nil || !ok {
 		return
 	}
+	if err = validateGoSignature("templ", r.Expression); err != nil {
+		return r, false, err
+	}
 
 	// Eat " {\n".
 	if _, ok, err = Must(until, msg).Parse(pi); err != nil || !ok {
@@ -61,172 +65,162 @@ type templateNodeParser[TUntil any] struct {
 var rawElements = parse.Any[RawElement](styleElement, scriptElement)
 
 func (p templateNodeParser[T]) Parse(pi *parse.Input) (op []Node, ok bool, err error) {
+	var errs Errors
 	for {
 		// Check if we've reached the end.
 		if p.until != nil {
 			start := pi.Index()
 			_, ok, err = p.until.Parse(pi)
+			if _, recovered := err.(Errors); recovered {
+				// The errors are found again when the nodes are parsed.
+				ok, err = true, nil
+			}
 			if err != nil {
 				return
 			}
 			if ok {
 				pi.Seek(start)
-				return op, true, nil
+				return op, true, errs.orNil()
 			}
 		}
 
-		// Try for valid nodes.
-		// Try for a doctype.
-		// <!DOCTYPE html>
-		var docTypeNode DocType
-		docTypeNode, ok, err = docTypeParser.Parse(pi)
-		if err != nil {
-			return
-		}
-		if ok {
-			op = append(op, docTypeNode)
+		start := pi.Position()
+		var node Node
+		node, ok, err = parseTemplateNode(pi)
+		if !errs.add(err) {
+			// Skip the rest of the line, and carry on with the next node.
+			e, recovered := recoverAt(pi, start, err)
+			if !recovered {
+				return
+			}
+			errs = append(errs, e)
 			continue
 		}
-
-		// Try for a raw <text>, <>, or <style> element (special behaviour - contents are not parsed).
-		var rawElementNode RawElement
-		rawElementNode, ok, err = rawElements.Parse(pi)
-		if err != nil {
-			return
-		}
 		if ok {
-			op = append(op, rawElementNode)
+			op = append(op, node)
 			continue
 		}
 
-		// Try for an element.
-		// <a>, <br/> etc.
-		var elementNode Element
-		elementNode, ok, err = element.Parse(pi)
-		if err != nil {
-			return
-		}
-		if ok {
-			op = append(op, elementNode)
-			continue
+		if p.until == nil {
+			// In this case, we're just reading as many nodes as we can until we can't read any more.
+			// If we've reached here, we couldn't find a node.
+			// The element parser checks the final node returned to make sure it's the expected close tag.
+			break
 		}
 
-		// Try for an if expression.
-		// if {}
-		var ifNode IfExpression
-		ifNode, ok, err = ifExpression.Parse(pi)
-		if err != nil {
-			return
-		}
-		if ok {
-			op = append(op, ifNode)
-			continue
-		}
+		err = parse.Error(fmt.Sprintf("%v not found", p.untilName), pi.Position())
+		return
+	}
 
-		// Try for a for expression.
-		// for {}
-		var forNode ForExpression
-		forNode, ok, err = forExpression.Parse(pi)
-		if err != nil {
-			return
-		}
-		if ok {
-			op = append(op, forNode)
-			continue
-		}
+	return op, true, errs.orNil()
+}
 
-		// Try for a switch expression.
-		// switch {}
-		var switchNode SwitchExpression
-		switchNode, ok, err = switchExpression.Parse(pi)
-		if err != nil {
-			return
-		}
-		if ok {
-			op = append(op, switchNode)
-			continue
-		}
+// parseTemplateNode parses a single node (element, call, if, switch, for, whitespace etc.)
+func parseTemplateNode(pi *parse.Input) (n Node, ok bool, err error) {
+	// Try for a doctype.
+	// <!DOCTYPE html>
+	var docTypeNode DocType
+	if docTypeNode, ok, err = docTypeParser.Parse(pi); err != nil || ok {
+		return docTypeNode, ok, err
+	}
 
-		// Try for a call template expression.
-		// {! TemplateName(a, b, c) }
-		var cteNode CallTemplateExpression
-		cteNode, ok, err = callTemplateExpression.Parse(pi)
-		if err != nil {
-			return
-		}
-		if ok {
-			op = append(op, cteNode)
-			continue
-		}
+	// Try for a comment.
+	// <!-- HTML comment -->
+	// // templ comment
+	var commentNode Comment
+	if commentNode, ok, err = commentParser.Parse(pi); err != nil || ok {
+		return commentNode, ok, err
+	}
 
-		// Try for a templ element expression.
-		// <!TemplateName(a, b, c) />
-		var templElementNode TemplElementExpression
-		templElementNode, ok, err = templElementExpression.Parse(pi)
-		if err != nil {
-			return
-		}
-		if ok {
-			op = append(op, templElementNode)
-			continue
-		}
+	// Try for a raw <text>, <>, or <style> element (special behaviour - contents are not parsed).
+	var rawElementNode RawElement
+	if rawElementNode, ok, err = rawElements.Parse(pi); err != nil || ok {
+		return rawElementNode, ok, err
+	}
 
-		// Try for a children element expression.
-		// { children... }
-		var childrenExpressionNode ChildrenExpression
-		childrenExpressionNode, ok, err = childrenExpression.Parse(pi)
-		if err != nil {
-			return
-		}
-		if ok {
-			op = append(op, childrenExpressionNode)
-			continue
-		}
+	// Try for an element.
+	// <a>, <br/> etc.
+	var elementNode Element
+	if elementNode, ok, err = element.Parse(pi); err != nil || ok {
+		return elementNode, ok, err
+	}
 
-		// Try for a string expression.
-		// { "abc" }
-		// { strings.ToUpper("abc") }
-		var stringExpressionNode StringExpression
-		stringExpressionNode, ok, err = stringExpression.Parse(pi)
-		if err != nil {
-			return
-		}
-		if ok {
-			op = append(op, stringExpressionNode)
-			continue
-		}
+	// Try for an if expression.
+	// if {}
+	var ifNode IfExpression
+	if ifNode, ok, err = ifExpression.Parse(pi); err != nil || ok {
+		return ifNode, ok, err
+	}
 
-		// Eat any whitespace.
-		var ws string
-		if ws, ok, err = parse.OptionalWhitespace.Parse(pi); err != nil || !ok {
-			return
-		}
-		if ok && len(ws) > 0 {
-			op = append(op, Whitespace{Value: ws})
-			continue
-		}
+	// Try for a for expression.
+	// for {}
+	var forNode ForExpression
+	if forNode, ok, err = forExpression.Parse(pi); err != nil || ok {
+		return forNode, ok, err
+	}
 
-		// Try for text.
-		// anything &amp; everything accepted...
-		var text Text
-		if text, ok, err = textParser.Parse(pi); err != nil {
-			return
-		}
-		if ok && len(text.Value) > 0 {
-			op = append(op, text)
-			continue
-		}
+	// Try for a switch expression.
+	// switch {}
+	var switchNode SwitchExpression
+	if switchNode, ok, err = switchExpression.Parse(pi); err != nil || ok {
+		return switchNode, ok, err
+	}
 
-		if p.until == nil {
-			// In this case, we're just reading as many nodes as we can until we can't read any more.
-			// If we've reached here, we couldn't find a node.
-			// The element parser checks the final node returned to make sure it's the expected close tag.
-			break
-		}
+	// Try for a call template expression.
+	// {! TemplateName(a, b, c) }
+	var cteNode CallTemplateExpression
+	if cteNode, ok, err = callTemplateExpression.Parse(pi); err != nil || ok {
+		return cteNode, ok, err
+	}
+
+	// Try for a templ element expression.
+	// <!TemplateName(a, b, c) />
+	var templElementNode TemplElementExpression
+	if templElementNode, ok, err = templElementExpression.Parse(pi); err != nil || ok {
+		return templElementNode, ok, err
+	}
+
+	// Try for Go code.
+	// {{ total := sum(items) }}
+	var goCodeNode GoCode
+	if goCodeNode, ok, err = goCode.Parse(pi); err != nil || ok {
+		return goCodeNode, ok, err
+	}
+
+	// Try for a children element expression.
+	// { children... }
+	var childrenExpressionNode ChildrenExpression
+	if childrenExpressionNode, ok, err = childrenExpression.Parse(pi); err != nil || ok {
+		return childrenExpressionNode, ok, err
+	}
+
+	// Try for a string expression.
+	// { "abc" }
+	// { strings.ToUpper("abc") }
+	var stringExpressionNode StringExpression
+	if stringExpressionNode, ok, err = stringExpression.Parse(pi); err != nil || ok {
+		return stringExpressionNode, ok, err
+	}
 
-		err = fmt.Errorf("%v not found", p.untilName)
+	// Eat any whitespace.
+	from := pi.Position()
+	var ws string
+	if ws, ok, err = parse.OptionalWhitespace.Parse(pi); err != nil || !ok {
 		return
 	}
+	if len(ws) > 0 {
+		return Whitespace{Value: ws, Range: NewRange(from, pi.Position())}, true, nil
+	}
+
+	// Try for text.
+	// anything &amp; everything accepted...
+	var text Text
+	if text, ok, err = textParser.Parse(pi); err != nil {
+		return
+	}
+	if ok && len(text.Value) > 0 {
+		return text, true, nil
+	}
 
-	return op, true, nil
+	return nil, false, nil
 }
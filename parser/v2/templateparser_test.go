@@ -58,6 +58,28 @@ func TestTemplateParser(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "template: with type parameters",
+			input: `templ List[T any, K comparable](items map[K]T) {
+}`,
+			expected: HTMLTemplate{
+				Expression: Expression{
+					Value: "List[T any, K comparable](items map[K]T)",
+					Range: Range{
+						From: Position{
+							Index: 6,
+							Line:  0,
+							Col:   6,
+						},
+						To: Position{
+							Index: 46,
+							Line:  0,
+							Col:   46,
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "template: no spaces",
 			input: `templ Name(){
@@ -280,7 +302,7 @@ func TestTemplateParser(t *testing.T) {
 							Element{
 								Name: "span",
 								Children: []Node{
-									Whitespace{"\n\t\t\t"},
+									Whitespace{Value: "\n\t\t\t"},
 									StringExpression{
 										Expression: Expression{
 											Value: `"span content"`,
@@ -298,7 +320,7 @@ func TestTemplateParser(t *testing.T) {
 											},
 										},
 									},
-									Whitespace{"\n\t\t"},
+									Whitespace{Value: "\n\t\t"},
 								},
 							},
 							Whitespace{
@@ -400,7 +422,7 @@ func TestTemplateParser(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			input := parse.NewInput(tt.input)
 			actual, ok, err := template.Parse(input)
-			diff := cmp.Diff(tt.expected, actual)
+			diff := cmp.Diff(tt.expected, actual, ignoreNodeRanges)
 			switch {
 			case tt.expectError && err == nil:
 				t.Errorf("expected an error got nil: %+v", actual)
@@ -428,18 +450,21 @@ func TestTemplateParserErrors(t *testing.T) {
 }`,
 			expected: "<span>: malformed open element: line 2, col 0",
 		},
+		{
+			name: "template: invalid type parameters",
+			input: `templ List[T any(items []T) {
+}`,
+			expected: "templ: missing ',' in parameter list: line 0, col 16",
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			input := parse.NewInput(tt.input)
-			_, ok, err := template.Parse(input)
+			_, _, err := template.Parse(input)
 			if err == nil {
 				t.Fatalf("expected error %q, got nil", tt.expected)
 			}
-			if ok {
-				t.Error("expected failure, but got success")
-			}
 			if diff := cmp.Diff(tt.expected, err.Error()); diff != "" {
 				t.Errorf(diff)
 			}
@@ -8,6 +8,8 @@ import (
 )
 
 var templBlockElementExpression = parse.Func(func(pi *parse.Input) (r TemplElementExpression, ok bool, err error) {
+	from := pi.Position()
+
 	// Check the prefix first.
 	if _, ok, err = parse.Rune('@').Parse(pi); err != nil || !ok {
 		return
@@ -18,6 +20,9 @@ var templBlockElementExpression = parse.Func(func(pi *parse.Input) (r TemplEleme
 	if r.Expression, ok, err = endOfStatementExpression.Parse(pi); err != nil || !ok {
 		return
 	}
+	if err = validateGoExpression("templ element", r.Expression); err != nil {
+		return r, false, err
+	}
 
 	// Eat " {\n".
 	if _, ok, err = Must(parse.All(openBraceWithOptionalPadding, parse.NewLine), "templ element: unterminated (missing closing '{\n')").Parse(pi); err != nil || !ok {
@@ -27,8 +32,9 @@ var templBlockElementExpression = parse.Func(func(pi *parse.Input) (r TemplEleme
 	// Once we've had the start of a for block, we must conclude the block.
 
 	// Node contents.
+	var errs Errors
 	np := newTemplateNodeParser(closeBraceWithOptionalPadding, "templ element closing brace")
-	if r.Children, ok, err = Must[[]Node](np, fmt.Sprintf("@%s: expected nodes, but none were found", r.Expression.Value)).Parse(pi); err != nil || !ok {
+	if r.Children, ok, err = Must[[]Node](np, fmt.Sprintf("@%s: expected nodes, but none were found", r.Expression.Value)).Parse(pi); !errs.add(err) || !ok {
 		return
 	}
 
@@ -36,12 +42,14 @@ var templBlockElementExpression = parse.Func(func(pi *parse.Input) (r TemplEleme
 	if _, ok, err = Must(closeBraceWithOptionalPadding, fmt.Sprintf("@%s: missing end (expected '}')", r.Expression.Value)).Parse(pi); err != nil || !ok {
 		return
 	}
+	r.Range = NewRange(from, pi.Position())
 
-	return r, true, nil
+	return r, true, errs.orNil()
 })
 
 var templSelfClosingElementExpression = parse.Func(func(pi *parse.Input) (e TemplElementExpression, ok bool, err error) {
 	start := pi.Index()
+	from := pi.Position()
 
 	// Check the prefix first.
 	if _, ok, err = parse.Rune('@').Parse(pi); err != nil || !ok {
@@ -59,6 +67,10 @@ var templSelfClosingElementExpression = parse.Func(func(pi *parse.Input) (e Temp
 		pi.Seek(start)
 		return e, false, nil
 	}
+	if err = validateGoExpression("templ element", e.Expression); err != nil {
+		return e, false, err
+	}
+	e.Range = NewRange(from, pi.Position())
 
 	return e, true, nil
 })
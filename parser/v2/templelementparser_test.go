@@ -86,7 +86,7 @@ func TestTemplElementExpressionParser(t *testing.T) {
 				Children: []Node{
 					Whitespace{Value: "\t\t\t"},
 					Element{Name: "a", Attributes: []Attribute{
-						ConstantAttribute{"href", "someurl"},
+						ConstantAttribute{Name: "href", Value: "someurl"},
 					}},
 					Whitespace{Value: "\n\t\t"},
 				},
@@ -140,7 +140,7 @@ func TestTemplElementExpressionParser(t *testing.T) {
 			if !ok {
 				t.Fatalf("unexpected failure for input %q", tt.input)
 			}
-			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+			if diff := cmp.Diff(tt.expected, actual, ignoreNodeRanges); diff != "" {
 				t.Error(diff)
 			}
 		})
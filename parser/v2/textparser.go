@@ -17,6 +17,7 @@ var textParser = parse.Func(func(pi *parse.Input) (t Text, ok bool, err error) {
 		err = parse.Error("textParser: unterminated text, expected tag open, templ expression open, or newline", from)
 		return
 	}
+	t.Range = NewRange(from, pi.Position())
 
 	return t, true, nil
 })
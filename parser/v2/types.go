@@ -68,21 +68,22 @@ func NewPosition(index int64, line, col uint32) Position {
 func NewExpression(value string, from, to parse.Position) Expression {
 	return Expression{
 		Value: value,
-		Range: Range{
-			From: Position{
-				Index: int64(from.Index),
-				Line:  uint32(from.Line),
-				Col:   uint32(from.Col),
-			},
-			To: Position{
-				Index: int64(to.Index),
-				Line:  uint32(to.Line),
-				Col:   uint32(to.Col),
-			},
-		},
+		Range: NewRange(from, to),
 	}
 }
 
+// NewRange creates a Range from parser positions.
+func NewRange(from, to parse.Position) Range {
+	return Range{
+		From: positionOf(from),
+		To:   positionOf(to),
+	}
+}
+
+func positionOf(p parse.Position) Position {
+	return NewPosition(int64(p.Index), uint32(p.Line), uint32(p.Col))
+}
+
 // Range of text within a file.
 type Range struct {
 	From Position
@@ -136,7 +137,7 @@ func (exp GoExpression) Write(w io.Writer, indent int) error {
 }
 
 func writeIndent(w io.Writer, level int, s string) (err error) {
-	if _, err = w.Write([]byte(strings.Repeat("\t", level))); err != nil {
+	if _, err = w.Write([]byte(indentString(w, level))); err != nil {
 		return
 	}
 	_, err = w.Write([]byte(s))
@@ -154,6 +155,7 @@ func (p Package) Write(w io.Writer, indent int) error {
 // Whitespace.
 type Whitespace struct {
 	Value string
+	Range Range
 }
 
 func (ws Whitespace) IsNode() bool { return true }
@@ -170,13 +172,16 @@ func (ws Whitespace) Write(w io.Writer, indent int) error {
 //	  background-image: url('./somewhere.png');
 //	}
 type CSSTemplate struct {
+	Type       CSSTemplateType
 	Name       Expression
+	Parameters Expression
 	Properties []CSSProperty
+	Range      Range
 }
 
 func (css CSSTemplate) IsTemplateFileNode() bool { return true }
 func (css CSSTemplate) Write(w io.Writer, indent int) error {
-	if err := writeIndent(w, indent, "css "+css.Name.Value+"() {\n"); err != nil {
+	if err := writeIndent(w, indent, "css "+css.Type.prefix()+css.Name.Value+"("+css.Parameters.Value+") {\n"); err != nil {
 		return err
 	}
 	for _, p := range css.Properties {
@@ -190,6 +195,31 @@ func (css CSSTemplate) Write(w io.Writer, indent int) error {
 	return nil
 }
 
+// CSSTemplateType is the kind of CSS that a css template creates.
+type CSSTemplateType int
+
+const (
+	// CSSTemplateTypeClass is a class.
+	// css Name() {
+	CSSTemplateTypeClass CSSTemplateType = iota
+	// CSSTemplateTypeKeyframes is a @keyframes animation.
+	// css keyframes Name() {
+	CSSTemplateTypeKeyframes
+	// CSSTemplateTypeFontFace is a @font-face rule.
+	// css fontface Name() {
+	CSSTemplateTypeFontFace
+)
+
+func (t CSSTemplateType) prefix() string {
+	switch t {
+	case CSSTemplateTypeKeyframes:
+		return "keyframes "
+	case CSSTemplateTypeFontFace:
+		return "fontface "
+	}
+	return ""
+}
+
 // CSSProperty is a CSS property and value pair.
 type CSSProperty interface {
 	IsCSSProperty() bool
@@ -200,6 +230,7 @@ type CSSProperty interface {
 type ConstantCSSProperty struct {
 	Name  string
 	Value string
+	Range Range
 }
 
 func (c ConstantCSSProperty) IsCSSProperty() bool { return true }
@@ -214,6 +245,7 @@ func (c ConstantCSSProperty) Write(w io.Writer, indent int) error {
 type ExpressionCSSProperty struct {
 	Name  string
 	Value StringExpression
+	Range Range
 }
 
 func (c ExpressionCSSProperty) IsCSSProperty() bool { return true }
@@ -230,9 +262,37 @@ func (c ExpressionCSSProperty) Write(w io.Writer, indent int) error {
 	return nil
 }
 
+// CSSRule is a nested rule in a css template. The selector is scoped to the
+// class of the template, where & refers to the class. At-rules, such as @media,
+// apply their properties to the enclosing selector.
+//
+//	&:hover {
+//		color: #ff0000;
+//	}
+type CSSRule struct {
+	// Selector, e.g. "&:hover", "> li", or "@media (max-width: 600px)".
+	Selector   string
+	Properties []CSSProperty
+	Range      Range
+}
+
+func (c CSSRule) IsCSSProperty() bool { return true }
+func (c CSSRule) Write(w io.Writer, indent int) error {
+	if err := writeIndent(w, indent, c.Selector+" {\n"); err != nil {
+		return err
+	}
+	for _, p := range c.Properties {
+		if err := p.Write(w, indent+1); err != nil {
+			return err
+		}
+	}
+	return writeIndent(w, indent, "}\n")
+}
+
 // <!DOCTYPE html>
 type DocType struct {
 	Value string
+	Range Range
 }
 
 func (dt DocType) IsNode() bool { return true }
@@ -250,6 +310,7 @@ func (dt DocType) Write(w io.Writer, indent int) error {
 type HTMLTemplate struct {
 	Expression Expression
 	Children   []Node
+	Range      Range
 }
 
 func (t HTMLTemplate) IsTemplateFileNode() bool { return true }
@@ -278,18 +339,57 @@ type Node interface {
 type Text struct {
 	// Value is the raw HTML encoded value.
 	Value string
+	Range Range
 }
 
 func (t Text) IsNode() bool { return true }
 func (t Text) Write(w io.Writer, indent int) error {
+	if fw, ok := shouldWrapText(w); ok {
+		return fw.writeWrappedText(indent, t.Value)
+	}
 	return writeIndent(w, indent, t.Value)
 }
 
+// CommentType is the syntax of a comment.
+type CommentType int
+
+const (
+	// CommentTypeHTML is an HTML comment, which is rendered to the output.
+	// <!-- ... -->
+	CommentTypeHTML CommentType = iota
+	// CommentTypeSingleLine is a templ comment, which is removed when code is generated.
+	// // ...
+	CommentTypeSingleLine
+	// CommentTypeMultiline is a templ comment, which is removed when code is generated.
+	// /* ... */
+	CommentTypeMultiline
+)
+
+// Comment within a template.
+type Comment struct {
+	// Contents of the comment, without the comment markers.
+	Contents string
+	Type     CommentType
+	Range    Range
+}
+
+func (c Comment) IsNode() bool { return true }
+func (c Comment) Write(w io.Writer, indent int) error {
+	switch c.Type {
+	case CommentTypeSingleLine:
+		return writeIndent(w, indent, "//"+c.Contents)
+	case CommentTypeMultiline:
+		return writeIndent(w, indent, "/*"+c.Contents+"*/")
+	}
+	return writeIndent(w, indent, "<!--"+c.Contents+"-->")
+}
+
 // <a .../> or <div ...>...</div>
 type Element struct {
 	Name       string
 	Attributes []Attribute
 	Children   []Node
+	Range      Range
 }
 
 var voidElements = map[string]struct{}{
@@ -333,6 +433,12 @@ func (e Element) containsBlockElement() bool {
 			continue
 		case Text:
 			continue
+		case Comment:
+			// Single line comments run to the end of the line, so can't be written inline.
+			if n.Type == CommentTypeSingleLine {
+				return true
+			}
+			continue
 		}
 		// Any template elements should be considered block.
 		return true
@@ -376,29 +482,22 @@ func containsNonTextNodes(nodes []Node) bool {
 
 func (e Element) IsNode() bool { return true }
 func (e Element) Write(w io.Writer, indent int) error {
+	if fw, ok := w.(*formatWriter); ok && e.preservesWhitespace() {
+		fw.preDepth++
+		defer func() { fw.preDepth-- }()
+	}
 	if err := writeIndent(w, indent, "<"+e.Name); err != nil {
 		return err
 	}
-	var previousWasMultiline bool
-	for i := 0; i < len(e.Attributes); i++ {
-		a := e.Attributes[i]
-		// Only the conditional attributes get indented.
-		var attrIndent int
-		if previousWasMultiline || a.IsMultilineAttr() {
-			attrIndent = indent + 1
-		} else {
-			if _, err := w.Write([]byte(" ")); err != nil {
-				return err
-			}
-		}
-		if err := a.Write(w, attrIndent); err != nil {
-			return err
-		}
-		previousWasMultiline = a.IsMultilineAttr()
-	}
 	var closeAngleBracketIndent int
-	if previousWasMultiline {
-		closeAngleBracketIndent = indent + 1
+	var err error
+	if shouldWrapAttributes(w, e) {
+		closeAngleBracketIndent, err = e.writeAttributesOnePerLine(w, indent)
+	} else {
+		closeAngleBracketIndent, err = e.writeAttributes(w, indent)
+	}
+	if err != nil {
+		return err
 	}
 	if e.hasNonWhitespaceChildren() {
 		if e.containsBlockElement() {
@@ -436,8 +535,99 @@ func (e Element) Write(w io.Writer, indent int) error {
 	return nil
 }
 
+// writeAttributes writes the attributes after the element name, returning the indentation
+// of the closing angle bracket.
+func (e Element) writeAttributes(w io.Writer, indent int) (closeAngleBracketIndent int, err error) {
+	var previousWasMultiline bool
+	for i := 0; i < len(e.Attributes); i++ {
+		a := e.Attributes[i]
+		// Only the conditional attributes get indented.
+		var attrIndent int
+		if previousWasMultiline || a.IsMultilineAttr() {
+			attrIndent = indent + 1
+		} else {
+			if _, err = w.Write([]byte(" ")); err != nil {
+				return
+			}
+		}
+		if err = a.Write(w, attrIndent); err != nil {
+			return
+		}
+		previousWasMultiline = a.IsMultilineAttr()
+	}
+	if previousWasMultiline {
+		closeAngleBracketIndent = indent + 1
+	}
+	return
+}
+
+// writeAttributesOnePerLine writes each attribute on its own line, returning the indentation
+// of the closing angle bracket, which is also on its own line.
+func (e Element) writeAttributesOnePerLine(w io.Writer, indent int) (closeAngleBracketIndent int, err error) {
+	var atLineStart bool
+	for i := 0; i < len(e.Attributes); i++ {
+		a := e.Attributes[i]
+		if a.IsMultilineAttr() {
+			// Conditional attributes start and end with a new line.
+			if err = a.Write(w, indent+1); err != nil {
+				return
+			}
+			atLineStart = true
+			continue
+		}
+		if !atLineStart {
+			if _, err = w.Write([]byte("\n")); err != nil {
+				return
+			}
+		}
+		if err = a.Write(w, indent+1); err != nil {
+			return
+		}
+		atLineStart = false
+	}
+	if !atLineStart {
+		if _, err = w.Write([]byte("\n")); err != nil {
+			return
+		}
+	}
+	return indent, nil
+}
+
+// preservesWhitespace returns true for elements where the browser displays whitespace as
+// it's written, so text mustn't be wrapped.
+func (e Element) preservesWhitespace() bool {
+	return strings.EqualFold(e.Name, "pre") || strings.EqualFold(e.Name, "textarea")
+}
+
 func writeNodesInline(w io.Writer, nodes []Node) error {
-	return writeNodes(w, 0, nodes, false)
+	return writeNodes(w, 0, joinInlineText(nodes), false)
+}
+
+// joinInlineText replaces whitespace next to text with a single space, and joins it with the
+// text. The generator keeps whitespace that's next to text, so dropping it would change what's
+// rendered, e.g. "{ name } again" would be formatted as "{ name }again".
+func joinInlineText(nodes []Node) (op []Node) {
+	for i, n := range nodes {
+		if _, isWhitespace := n.(Whitespace); isWhitespace {
+			if i == 0 || i == len(nodes)-1 {
+				continue
+			}
+			_, prevIsText := nodes[i-1].(Text)
+			_, nextIsText := nodes[i+1].(Text)
+			if !prevIsText && !nextIsText {
+				continue
+			}
+			n = Text{Value: " "}
+		}
+		if t, isText := n.(Text); isText && len(op) > 0 {
+			if prev, prevIsText := op[len(op)-1].(Text); prevIsText {
+				op[len(op)-1] = Text{Value: prev.Value + t.Value}
+				continue
+			}
+		}
+		op = append(op, n)
+	}
+	return op
 }
 
 func writeNodesBlock(w io.Writer, indent int, nodes []Node) error {
@@ -465,6 +655,7 @@ type RawElement struct {
 	Name       string
 	Attributes []Attribute
 	Contents   string
+	Range      Range
 }
 
 func (e RawElement) IsNode() bool { return true }
@@ -505,7 +696,8 @@ type Attribute interface {
 
 // <hr noshade/>
 type BoolConstantAttribute struct {
-	Name string
+	Name  string
+	Range Range
 }
 
 func (bca BoolConstantAttribute) IsMultilineAttr() bool { return false }
@@ -520,20 +712,64 @@ func (bca BoolConstantAttribute) Write(w io.Writer, indent int) error {
 type ConstantAttribute struct {
 	Name  string
 	Value string
+	Range Range
 }
 
 func (ca ConstantAttribute) IsMultilineAttr() bool { return false }
 func (ca ConstantAttribute) String() string {
-	return ca.Name + `="` + html.EscapeString(ca.Value) + `"`
+	value := html.EscapeString(ca.Value)
+	if containsInterpolation(ca.Name, ca.Value) {
+		value = strings.ReplaceAll(value, "{", "&#123;")
+	}
+	return ca.Name + `="` + value + `"`
 }
 func (ca ConstantAttribute) Write(w io.Writer, indent int) error {
 	return writeIndent(w, indent, ca.String())
 }
 
+// href="/users/{ id }/edit"
+type InterpolatedAttribute struct {
+	Name  string
+	Parts []InterpolatedAttributePart
+	Range Range
+}
+
+// InterpolatedAttributePart is either text, or a Go expression within the value of an
+// InterpolatedAttribute.
+type InterpolatedAttributePart struct {
+	// Value is the unescaped text of the part, if the part isn't an expression.
+	Value string
+	// Expression is set if the part is an expression.
+	Expression Expression
+}
+
+// IsExpression returns true if the part is a Go expression.
+func (p InterpolatedAttributePart) IsExpression() bool { return p.Expression.Value != "" }
+
+func (ia InterpolatedAttribute) IsMultilineAttr() bool { return false }
+func (ia InterpolatedAttribute) String() string {
+	var sb strings.Builder
+	sb.WriteString(ia.Name + `="`)
+	for _, p := range ia.Parts {
+		if p.IsExpression() {
+			sb.WriteString(`{ ` + p.Expression.Value + ` }`)
+			continue
+		}
+		// Braces in text are escaped, so that they're not parsed as expressions.
+		sb.WriteString(strings.ReplaceAll(html.EscapeString(p.Value), "{", "&#123;"))
+	}
+	sb.WriteString(`"`)
+	return sb.String()
+}
+func (ia InterpolatedAttribute) Write(w io.Writer, indent int) error {
+	return writeIndent(w, indent, ia.String())
+}
+
 // href={ templ.Bool(...) }
 type BoolExpressionAttribute struct {
 	Name       string
 	Expression Expression
+	Range      Range
 }
 
 func (ea BoolExpressionAttribute) IsMultilineAttr() bool { return false }
@@ -548,6 +784,7 @@ func (ea BoolExpressionAttribute) Write(w io.Writer, indent int) error {
 type ExpressionAttribute struct {
 	Name       string
 	Expression Expression
+	Range      Range
 }
 
 func (ea ExpressionAttribute) IsMultilineAttr() bool { return false }
@@ -565,7 +802,16 @@ func (ea ExpressionAttribute) Write(w io.Writer, indent int) error {
 type ConditionalAttribute struct {
 	Expression Expression
 	Then       []Attribute
+	ElseIfs    []ConditionalAttributeElseIf
 	Else       []Attribute
+	Range      Range
+}
+
+// ConditionalAttributeElseIf is an else if branch of a ConditionalAttribute.
+type ConditionalAttributeElseIf struct {
+	Expression Expression
+	Then       []Attribute
+	Range      Range
 }
 
 func (ca ConditionalAttribute) IsMultilineAttr() bool { return true }
@@ -603,6 +849,22 @@ func (ca ConditionalAttribute) Write(w io.Writer, indent int) error {
 	if err := writeIndent(w, indent, "}"); err != nil {
 		return err
 	}
+	for _, elseIf := range ca.ElseIfs {
+		if _, err := w.Write([]byte(" else if " + elseIf.Expression.Value + " {\n")); err != nil {
+			return err
+		}
+		for _, attr := range elseIf.Then {
+			if err := attr.Write(w, indent+1); err != nil {
+				return err
+			}
+			if _, err := w.Write([]byte("\n")); err != nil {
+				return err
+			}
+		}
+		if err := writeIndent(w, indent, "}"); err != nil {
+			return err
+		}
+	}
 	if len(ca.Else) == 0 {
 		if _, err := w.Write([]byte("\n")); err != nil {
 			return err
@@ -640,6 +902,7 @@ func (ca ConditionalAttribute) Write(w io.Writer, indent int) error {
 type CallTemplateExpression struct {
 	// Expression returns a template to execute.
 	Expression Expression
+	Range      Range
 }
 
 func (cte CallTemplateExpression) IsNode() bool { return true }
@@ -656,6 +919,7 @@ type TemplElementExpression struct {
 	Expression Expression
 	// Children returns the elements in a block element.
 	Children []Node
+	Range    Range
 }
 
 func (tee TemplElementExpression) IsNode() bool { return true }
@@ -677,7 +941,9 @@ func (tee TemplElementExpression) Write(w io.Writer, indent int) error {
 
 // ChildrenExpression can be used to rended the children of a templ element.
 // { children ... }
-type ChildrenExpression struct{}
+type ChildrenExpression struct {
+	Range Range
+}
 
 func (ChildrenExpression) IsNode() bool { return true }
 func (ChildrenExpression) Write(w io.Writer, indent int) error {
@@ -692,7 +958,16 @@ func (ChildrenExpression) Write(w io.Writer, indent int) error {
 type IfExpression struct {
 	Expression Expression
 	Then       []Node
+	ElseIfs    []ElseIfExpression
 	Else       []Node
+	Range      Range
+}
+
+// ElseIfExpression is an else if branch of an IfExpression.
+type ElseIfExpression struct {
+	Expression Expression
+	Then       []Node
+	Range      Range
 }
 
 func (n IfExpression) IsNode() bool { return true }
@@ -705,6 +980,14 @@ func (n IfExpression) Write(w io.Writer, indent int) error {
 		return err
 	}
 	indent--
+	for _, elseIf := range n.ElseIfs {
+		if err := writeIndent(w, indent, "} else if "+elseIf.Expression.Value+" {\n"); err != nil {
+			return err
+		}
+		if err := writeNodesBlock(w, indent+1, elseIf.Then); err != nil {
+			return err
+		}
+	}
 	if len(n.Else) > 0 {
 		if err := writeIndent(w, indent, "} else {\n"); err != nil {
 			return err
@@ -725,6 +1008,7 @@ func (n IfExpression) Write(w io.Writer, indent int) error {
 type SwitchExpression struct {
 	Expression Expression
 	Cases      []CaseExpression
+	Range      Range
 }
 
 func (se SwitchExpression) IsNode() bool { return true }
@@ -753,6 +1037,7 @@ func (se SwitchExpression) Write(w io.Writer, indent int) error {
 type CaseExpression struct {
 	Expression Expression
 	Children   []Node
+	Range      Range
 }
 
 //	for i, v := range p.Addresses {
@@ -761,6 +1046,7 @@ type CaseExpression struct {
 type ForExpression struct {
 	Expression Expression
 	Children   []Node
+	Range      Range
 }
 
 func (fe ForExpression) IsNode() bool { return true }
@@ -781,6 +1067,7 @@ func (fe ForExpression) Write(w io.Writer, indent int) error {
 // { ... }
 type StringExpression struct {
 	Expression Expression
+	Range      Range
 }
 
 func (se StringExpression) IsNode() bool                  { return true }
@@ -789,11 +1076,71 @@ func (se StringExpression) Write(w io.Writer, indent int) error {
 	return writeIndent(w, indent, `{ `+se.Expression.Value+` }`)
 }
 
+// GoCode is a block of Go statements within a template, which is copied into the
+// generated code.
+// {{ total := sum(items) }}
+type GoCode struct {
+	Expression Expression
+	Range      Range
+}
+
+func (gc GoCode) IsNode() bool { return true }
+func (gc GoCode) Write(w io.Writer, indent int) error {
+	if !strings.Contains(gc.Expression.Value, "\n") {
+		return writeIndent(w, indent, `{{ `+gc.Expression.Value+` }}`)
+	}
+	if err := writeIndent(w, indent, "{{\n"); err != nil {
+		return err
+	}
+	for _, line := range dedent(gc.Expression.Value) {
+		if line == "" {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := writeIndent(w, indent+1, line+"\n"); err != nil {
+			return err
+		}
+	}
+	return writeIndent(w, indent, "}}")
+}
+
+// dedent splits Go code into lines, and removes the indentation that the lines after the
+// first have in common. The first line starts after the opening braces, so it has none.
+func dedent(s string) (lines []string) {
+	lines = strings.Split(s, "\n")
+	var prefix string
+	var prefixSet bool
+	for _, line := range lines[1:] {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if !prefixSet {
+			prefix, prefixSet = indent, true
+			continue
+		}
+		for !strings.HasPrefix(indent, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	for i := range lines {
+		if strings.TrimSpace(lines[i]) == "" {
+			lines[i] = ""
+			continue
+		}
+		lines[i] = strings.TrimPrefix(lines[i], prefix)
+	}
+	return lines
+}
+
 // ScriptTemplate is a script block.
 type ScriptTemplate struct {
 	Name       Expression
 	Parameters Expression
 	Value      string
+	Range      Range
 }
 
 func (s ScriptTemplate) IsTemplateFileNode() bool { return true }
@@ -202,6 +202,96 @@ templ input(items []string) {
 	</div>
 }
 
+`,
+		},
+		{
+			name: "else if chains are formatted",
+			input: ` // first line removed to make indentation clear in Go code
+package test
+
+templ input(n int) {
+<div
+if n == 1 {
+class="one"
+} else if n == 2 {
+class="two"
+}
+>if n == 1 {
+<span>One</span>
+	} else if n == 2 {
+<span>Two</span>
+} else   if n == 3 {
+<span>Three</span>
+} else {
+<span>Other</span>
+}
+</div>
+}
+`,
+			expected: `// first line removed to make indentation clear in Go code
+package test
+
+templ input(n int) {
+	<div
+		if n == 1 {
+			class="one"
+		} else if n == 2 {
+			class="two"
+		}
+		>
+		if n == 1 {
+			<span>One</span>
+		} else if n == 2 {
+			<span>Two</span>
+		} else if n == 3 {
+			<span>Three</span>
+		} else {
+			<span>Other</span>
+		}
+	</div>
+}
+
+`,
+		},
+		{
+			name: "go code is placed on a new line, and indented",
+			input: ` // first line removed to make indentation clear in Go code
+package test
+
+templ nested(items []int) {
+<div>{{ total := sum(items) }}{ strconv.Itoa(total) }</div>
+<ul>
+{{
+		max := 0
+		for _, item := range items {
+			max = item
+		}
+
+		min := 0
+}}
+</ul>
+}
+`,
+			expected: `// first line removed to make indentation clear in Go code
+package test
+
+templ nested(items []int) {
+	<div>
+		{{ total := sum(items) }}
+		{ strconv.Itoa(total) }
+	</div>
+	<ul>
+		{{
+			max := 0
+			for _, item := range items {
+				max = item
+			}
+
+			min := 0
+		}}
+	</ul>
+}
+
 `,
 		},
 		{
@@ -254,6 +344,88 @@ css ClassName() {
 	color: { constants.White };
 }
 
+`,
+		},
+		{
+			name: "css parameters are kept",
+			input: ` // first line removed to make indentation clear in Go code
+package test
+
+css ClassName(color string, pad int) {
+color: { color };
+}
+`,
+			expected: `// first line removed to make indentation clear in Go code
+package test
+
+css ClassName(color string, pad int) {
+	color: { color };
+}
+
+`,
+		},
+		{
+			name: "css nested rules are indented",
+			input: ` // first line removed to make indentation clear in Go code
+package test
+
+css ClassName() {
+color: #000000;
+&:hover {
+color: { hover };
+}
+  @media (max-width: 600px)   {
+    li {
+display: block;
+    }
+  }
+}
+`,
+			expected: `// first line removed to make indentation clear in Go code
+package test
+
+css ClassName() {
+	color: #000000;
+	&:hover {
+		color: { hover };
+	}
+	@media (max-width: 600px) {
+		li {
+			display: block;
+		}
+	}
+}
+
+`,
+		},
+		{
+			name: "css keyframes and fontface templates are formatted",
+			input: ` // first line removed to make indentation clear in Go code
+package test
+
+css keyframes   fadeIn() {
+from {
+opacity: 0;
+}
+}
+
+css fontface brand() {
+font-weight: 400;
+}
+`,
+			expected: `// first line removed to make indentation clear in Go code
+package test
+
+css keyframes fadeIn() {
+	from {
+		opacity: 0;
+	}
+}
+
+css fontface brand() {
+	font-weight: 400;
+}
+
 `,
 		},
 		{
@@ -310,6 +482,64 @@ templ table(accountNumber string, registration string) {
 	</table>
 }
 
+`,
+		},
+		{
+			name: "type parameters and type arguments are kept",
+			input: ` // first line removed to make indentation clear
+package test
+
+templ List[T any](items []T, row func(T) templ.Component) {
+<ul>for _, item := range items {
+<li>{! row(item) }</li>
+}</ul>
+}
+
+templ Page(users []User) {
+@List[User](users, userRow)
+@List[User](users, userRow) {
+<p>Children</p>
+}
+}
+`,
+			expected: ` // first line removed to make indentation clear
+package test
+
+templ List[T any](items []T, row func(T) templ.Component) {
+	<ul>
+		for _, item := range items {
+			<li>
+				{! row(item) }
+			</li>
+		}
+	</ul>
+}
+
+templ Page(users []User) {
+	@List[User](users, userRow)
+	@List[User](users, userRow) {
+		<p>Children</p>
+	}
+}
+
+`,
+		},
+		{
+			name: "expressions in attribute values are padded, and escaped braces are kept",
+			input: ` // first line removed to make indentation clear
+package test
+
+templ user(id string) {
+	<a href="/users/{id}/edit" title="&#123; id }" x-data="{ open: false }">Edit</a>
+}
+`,
+			expected: ` // first line removed to make indentation clear
+package test
+
+templ user(id string) {
+	<a href="/users/{ id }/edit" title="&#123; id }" x-data="{ open: false }">Edit</a>
+}
+
 `,
 		},
 		{
@@ -417,6 +647,84 @@ templ conditionalAttributes(addClass bool) {
 		width="300">Content</div>
 }
 
+`,
+		},
+		{
+			name: "whitespace between text and inline expressions and elements is kept",
+			input: ` // first line removed to make indentation clear in Go code
+package test
+
+templ input(name string) {
+	<p>Hello { name }, welcome <b>back</b> again</p>
+}
+
+`,
+			expected: `// first line removed to make indentation clear in Go code
+package test
+
+templ input(name string) {
+	<p>Hello { name }, welcome <b>back</b> again</p>
+}
+
+`,
+		},
+		{
+			name: "text split over multiple lines is joined with a space",
+			input: ` // first line removed to make indentation clear in Go code
+package test
+
+templ input() {
+	<p>The quick brown fox
+		jumps over the lazy dog</p>
+}
+
+`,
+			expected: `// first line removed to make indentation clear in Go code
+package test
+
+templ input() {
+	<p>The quick brown fox jumps over the lazy dog</p>
+}
+
+`,
+		},
+		{
+			name: "comments are preserved",
+			input: ` // first line removed to make indentation clear in Go code
+package test
+
+templ input() {
+	<!-- HTML comment -->
+	// templ comment
+	<div>
+	/* multiline
+	templ comment */
+	<p>Text <!-- inline comment --> more text</p>
+	</div>
+	<ul>
+		// comment in a list
+		<li>Item</li>
+	</ul>
+}
+
+`,
+			expected: `// first line removed to make indentation clear in Go code
+package test
+
+templ input() {
+	<!-- HTML comment -->
+	// templ comment
+	<div>
+		/* multiline
+	templ comment */
+		<p>Text <!-- inline comment --> more text</p>
+	</div>
+	<ul>
+		// comment in a list
+		<li>Item</li>
+	</ul>
+}
+
 `,
 		},
 	}
@@ -443,3 +751,177 @@ templ conditionalAttributes(addClass bool) {
 		})
 	}
 }
+
+func TestFormattingWithOptions(t *testing.T) {
+	var tests = []struct {
+		name     string
+		options  FormatOptions
+		input    string
+		expected string
+	}{
+		{
+			name:    "default options match the default formatting",
+			options: DefaultFormatOptions(),
+			input: ` // first line removed to make indentation clear in Go code
+package test
+
+templ input() {
+	<div hx-get="/items" hx-target="#items" hx-swap="outerHTML" hx-trigger="load"><span>Items</span></div>
+}
+
+`,
+			expected: `// first line removed to make indentation clear in Go code
+package test
+
+templ input() {
+	<div hx-get="/items" hx-target="#items" hx-swap="outerHTML" hx-trigger="load"><span>Items</span></div>
+}
+
+`,
+		},
+		{
+			name:    "spaces can be used for indentation",
+			options: FormatOptions{UseSpaces: true, TabWidth: 2},
+			input: ` // first line removed to make indentation clear in Go code
+package test
+
+templ input() {
+	<div>
+		<p>Text</p>
+	</div>
+}
+
+`,
+			expected: `// first line removed to make indentation clear in Go code
+package test
+
+templ input() {
+  <div>
+    <p>Text</p>
+  </div>
+}
+
+`,
+		},
+		{
+			name:    "attributes are written one per line if the tag is longer than the max line width",
+			options: FormatOptions{TabWidth: 4, MaxLineWidth: 60},
+			input: ` // first line removed to make indentation clear in Go code
+package test
+
+templ input() {
+	<div hx-get="/items" hx-target="#items" hx-swap="outerHTML" hx-trigger="load"><span>Items</span></div>
+	<button type="submit">Submit</button>
+	<input type="text" name="search" placeholder="Search for items"/>
+}
+
+`,
+			expected: `// first line removed to make indentation clear in Go code
+package test
+
+templ input() {
+	<div
+		hx-get="/items"
+		hx-target="#items"
+		hx-swap="outerHTML"
+		hx-trigger="load"
+	><span>Items</span></div>
+	<button type="submit">Submit</button>
+	<input
+		type="text"
+		name="search"
+		placeholder="Search for items"
+	/>
+}
+
+`,
+		},
+		{
+			name:    "attributes are written one per line if there are more than the max per line",
+			options: FormatOptions{TabWidth: 4, MaxAttributesPerLine: 2},
+			input: ` // first line removed to make indentation clear in Go code
+package test
+
+templ input(active bool) {
+	<a href="/" class="link">Home</a>
+	<a href="/" class="link" if active {
+		aria-current="page"
+	} target="_blank">Home</a>
+}
+
+`,
+			expected: `// first line removed to make indentation clear in Go code
+package test
+
+templ input(active bool) {
+	<a href="/" class="link">Home</a>
+	<a
+		href="/"
+		class="link"
+		if active {
+			aria-current="page"
+		}
+		target="_blank"
+	>Home</a>
+}
+
+`,
+		},
+		{
+			name:    "text is wrapped at the max line width",
+			options: FormatOptions{TabWidth: 4, MaxLineWidth: 40, WrapText: true},
+			input: ` // first line removed to make indentation clear in Go code
+package test
+
+templ input() {
+	<p>The quick brown fox jumps over the lazy dog, then runs away.</p>
+	<pre>The quick brown fox jumps over the lazy dog, then runs away.</pre>
+}
+
+`,
+			expected: `// first line removed to make indentation clear in Go code
+package test
+
+templ input() {
+	<p>The quick brown fox jumps over
+		the lazy dog, then runs away.</p>
+	<pre>The quick brown fox jumps over the lazy dog, then runs away.</pre>
+}
+
+`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			// Remove the first line of the test data.
+			input := strings.SplitN(tt.input, "\n", 2)[1]
+			expected := strings.SplitN(tt.expected, "\n", 2)[1]
+
+			template, err := ParseString(input)
+			if err != nil {
+				t.Fatalf("failed to parse template: %v", err)
+			}
+			w := new(strings.Builder)
+			if err = template.WriteWithOptions(w, tt.options); err != nil {
+				t.Fatalf("failed to write template: %v", err)
+			}
+			if diff := cmp.Diff(expected, w.String()); diff != "" {
+				t.Error(diff)
+			}
+
+			// Formatting the output again shouldn't change it.
+			template, err = ParseString(w.String())
+			if err != nil {
+				t.Fatalf("failed to parse formatted template: %v", err)
+			}
+			again := new(strings.Builder)
+			if err = template.WriteWithOptions(again, tt.options); err != nil {
+				t.Fatalf("failed to write formatted template: %v", err)
+			}
+			if diff := cmp.Diff(w.String(), again.String()); diff != "" {
+				t.Errorf("formatting is not stable:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package parser
+
+// A Visitor's Visit method is invoked for each node encountered by Walk.
+// If the result visitor w is not nil, Walk visits each of the children
+// of node with the visitor w, followed by a call of w.Visit(nil).
+//
+// The node is a TemplateFile, Package, TemplateFileNode, Node, Attribute,
+// CSSProperty, CaseExpression, ElseIfExpression, ConditionalAttributeElseIf or
+// InterpolatedAttributePart.
+type Visitor interface {
+	Visit(node any) (w Visitor)
+}
+
+// Walk traverses a template file in depth-first order: It starts by calling
+// v.Visit(node); node must not be nil. If the visitor w returned by v.Visit(node)
+// is not nil, Walk is invoked recursively with visitor w for each of the non-nil
+// children of node, followed by a call of w.Visit(nil).
+func Walk(v Visitor, node any) {
+	if v = v.Visit(node); v == nil {
+		return
+	}
+
+	switch n := node.(type) {
+	case TemplateFile:
+		Walk(v, n.Package)
+		for _, c := range n.Nodes {
+			Walk(v, c)
+		}
+	case HTMLTemplate:
+		walkNodes(v, n.Children)
+	case CSSTemplate:
+		for _, p := range n.Properties {
+			Walk(v, p)
+		}
+	case ExpressionCSSProperty:
+		Walk(v, n.Value)
+	case CSSRule:
+		for _, p := range n.Properties {
+			Walk(v, p)
+		}
+	case Element:
+		walkAttributes(v, n.Attributes)
+		walkNodes(v, n.Children)
+	case RawElement:
+		walkAttributes(v, n.Attributes)
+	case ConditionalAttribute:
+		walkAttributes(v, n.Then)
+		for _, elseIf := range n.ElseIfs {
+			Walk(v, elseIf)
+		}
+		walkAttributes(v, n.Else)
+	case ConditionalAttributeElseIf:
+		walkAttributes(v, n.Then)
+	case InterpolatedAttribute:
+		for _, part := range n.Parts {
+			Walk(v, part)
+		}
+	case TemplElementExpression:
+		walkNodes(v, n.Children)
+	case IfExpression:
+		walkNodes(v, n.Then)
+		for _, elseIf := range n.ElseIfs {
+			Walk(v, elseIf)
+		}
+		walkNodes(v, n.Else)
+	case ElseIfExpression:
+		walkNodes(v, n.Then)
+	case SwitchExpression:
+		for _, c := range n.Cases {
+			Walk(v, c)
+		}
+	case CaseExpression:
+		walkNodes(v, n.Children)
+	case ForExpression:
+		walkNodes(v, n.Children)
+	}
+
+	v.Visit(nil)
+}
+
+func walkNodes(v Visitor, nodes []Node) {
+	for _, n := range nodes {
+		Walk(v, n)
+	}
+}
+
+func walkAttributes(v Visitor, attributes []Attribute) {
+	for _, a := range attributes {
+		Walk(v, a)
+	}
+}
+
+type inspector func(node any) bool
+
+func (f inspector) Visit(node any) Visitor {
+	if f(node) {
+		return f
+	}
+	return nil
+}
+
+// Inspect traverses a template file in depth-first order: It starts by calling
+// f(node); node must not be nil. If f returns true, Inspect invokes f recursively
+// for each of the children of node, followed by a call of f(nil).
+func Inspect(node any, f func(node any) bool) {
+	Walk(inspector(f), node)
+}
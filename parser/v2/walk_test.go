@@ -0,0 +1,126 @@
+package parser
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const walkTemplate = `package main
+
+templ Name(items []string) {
+	<div class="a" title="Hello, { name }" if ok {
+		disabled
+	} else if other {
+		autofocus
+	} else {
+		hidden
+	}>
+		// comment
+		if ok {
+			<span>{ "a" }</span>
+		} else if other {
+			<hr/>
+		} else {
+			<br/>
+		}
+		for _, item := range items {
+			@Item(item)
+		}
+		switch x {
+			case 1:
+				<b>1</b>
+		}
+	</div>
+}
+
+css Style() {
+	color: { red };
+	&:hover {
+		color: { blue };
+	}
+}
+`
+
+func TestInspect(t *testing.T) {
+	tf, err := ParseString(walkTemplate)
+	if err != nil {
+		t.Fatalf("failed to parse template: %v", err)
+	}
+	var actual []string
+	Inspect(tf, func(n any) bool {
+		switch n := n.(type) {
+		case nil, Whitespace:
+			return true
+		case Element:
+			actual = append(actual, "<"+n.Name+">")
+		case InterpolatedAttributePart:
+			if n.IsExpression() {
+				actual = append(actual, "{ "+n.Expression.Value+" }")
+				break
+			}
+			actual = append(actual, n.Value)
+		default:
+			actual = append(actual, fmt.Sprintf("%T", n))
+		}
+		return true
+	})
+	expected := []string{
+		"parser.TemplateFile",
+		"parser.Package",
+		"parser.HTMLTemplate",
+		"<div>",
+		"parser.ConstantAttribute",
+		"parser.InterpolatedAttribute",
+		"Hello, ",
+		"{ name }",
+		"parser.ConditionalAttribute",
+		"parser.BoolConstantAttribute",
+		"parser.ConditionalAttributeElseIf",
+		"parser.BoolConstantAttribute",
+		"parser.BoolConstantAttribute",
+		"parser.Comment",
+		"parser.IfExpression",
+		"<span>",
+		"parser.StringExpression",
+		"parser.ElseIfExpression",
+		"<hr>",
+		"<br>",
+		"parser.ForExpression",
+		"parser.TemplElementExpression",
+		"parser.SwitchExpression",
+		"parser.CaseExpression",
+		"<b>",
+		"parser.Text",
+		"parser.CSSTemplate",
+		"parser.ExpressionCSSProperty",
+		"parser.StringExpression",
+		"parser.CSSRule",
+		"parser.ExpressionCSSProperty",
+		"parser.StringExpression",
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestInspectSkipsChildren(t *testing.T) {
+	tf, err := ParseString(walkTemplate)
+	if err != nil {
+		t.Fatalf("failed to parse template: %v", err)
+	}
+	var elements []string
+	Inspect(tf, func(n any) bool {
+		if _, isIf := n.(IfExpression); isIf {
+			return false
+		}
+		if e, isElement := n.(Element); isElement {
+			elements = append(elements, e.Name)
+		}
+		return true
+	})
+	if diff := cmp.Diff([]string{"div", "b"}, elements); diff != "" {
+		t.Error(diff)
+	}
+}
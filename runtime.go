@@ -6,12 +6,15 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"html"
 	"io"
 	"net/http"
+	"reflect"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -123,6 +126,72 @@ func EscapeString(s string) string {
 	return html.EscapeString(s)
 }
 
+// Stringable is the set of types that can be used in string expressions: strings, bools and
+// numbers, including types based on them, e.g. time.Duration.
+type Stringable interface {
+	~string | ~bool |
+		~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~complex64 | ~complex128
+}
+
+// ToString converts the value of a string expression to a string. Types that implement
+// fmt.Stringer are converted using their String method. Expressions that return a value and
+// an error, e.g. { user.Name() }, pass the error in errs, and the error is returned.
+//
+// Values of other types, such as slices and maps, don't satisfy the constraint, so they fail
+// to compile instead of failing when the template is rendered. Structs, pointers and
+// interfaces that implement fmt.Stringer are converted by StringerToString instead.
+func ToString[T Stringable](v T, errs ...error) (string, error) {
+	for _, err := range errs {
+		if err != nil {
+			return "", err
+		}
+	}
+	switch v := any(v).(type) {
+	case string:
+		return v, nil
+	case fmt.Stringer:
+		return v.String(), nil
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 64), nil
+	}
+	return fmt.Sprint(v), nil
+}
+
+// StringerToString converts the value of a string expression that implements fmt.Stringer, but
+// isn't a string, bool or number, e.g. time.Time or *url.URL. The generator uses it instead of
+// ToString when type checking shows that the expression is a fmt.Stringer.
+//
+// A nil value returns an error, instead of panicking when its String method is called.
+func StringerToString(v fmt.Stringer, errs ...error) (string, error) {
+	for _, err := range errs {
+		if err != nil {
+			return "", err
+		}
+	}
+	if v == nil {
+		return "", errors.New("templ: nil fmt.Stringer in string expression")
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return "", fmt.Errorf("templ: nil %T in string expression", v)
+	}
+	return v.String(), nil
+}
+
 // Bool attribute value.
 func Bool(value bool) bool {
 	return value
@@ -197,6 +266,8 @@ func (cp *cssProcessor) Add(item any) {
 		}
 	case func() CSSClass:
 		cp.AddSanitized(c().ClassName(), true)
+	case ComponentCSSAtRule:
+		// At-rules are rendered with the classes, but aren't classes.
 	default:
 		cp.AddSanitized(unknownTypeClassName, true)
 	}
@@ -294,6 +365,15 @@ func (css ComponentCSSClass) ClassName() string {
 	return css.ID
 }
 
+// ComponentCSSAtRule is a @keyframes or @font-face rule created by a css keyframes or
+// css fontface template.
+type ComponentCSSAtRule struct {
+	// Name of the animation or font family, will be autogenerated.
+	Name string
+	// Definition of the CSS.
+	Rule SafeCSS
+}
+
 // CSSID calculates an ID.
 func CSSID(name string, css string) string {
 	sum := sha256.Sum256([]byte(css))
@@ -305,6 +385,8 @@ func CSSID(name string, css string) string {
 // CSS if the request path matches, or updates the HTTP context to ensure that any handlers that
 // use templ.Components skip rendering <style> elements for classes that are included in the global
 // stylesheet. By default, the stylesheet path is /styles/templ.css
+//
+// To include @keyframes and @font-face rules in the stylesheet, use WithAtRules.
 func NewCSSMiddleware(next http.Handler, classes ...ComponentCSSClass) CSSMiddleware {
 	return CSSMiddleware{
 		Path:       "/styles/templ.css",
@@ -313,6 +395,13 @@ func NewCSSMiddleware(next http.Handler, classes ...ComponentCSSClass) CSSMiddle
 	}
 }
 
+// WithAtRules returns a copy of the middleware that also includes the @keyframes and @font-face
+// rules created by css keyframes and css fontface templates in the global stylesheet.
+func (cssm CSSMiddleware) WithAtRules(rules ...ComponentCSSAtRule) CSSMiddleware {
+	cssm.CSSHandler.AtRules = append(append([]ComponentCSSAtRule(nil), cssm.CSSHandler.AtRules...), rules...)
+	return cssm
+}
+
 // CSSMiddleware renders a global stylesheet.
 type CSSMiddleware struct {
 	Path       string
@@ -330,6 +419,9 @@ func (cssm CSSMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	for _, c := range cssm.CSSHandler.Classes {
 		v.addClass(c.ID)
 	}
+	for _, r := range cssm.CSSHandler.AtRules {
+		v.addClass(r.Name)
+	}
 	// Serve the request. Templ components will use the updated context
 	// to know to skip rendering <style> elements for any component CSS
 	// classes that have been included in the global stylesheet.
@@ -349,10 +441,18 @@ func NewCSSHandler(classes ...ComponentCSSClass) CSSHandler {
 type CSSHandler struct {
 	Logger  func(err error)
 	Classes []ComponentCSSClass
+	// AtRules are written before the classes, so that the classes can use them.
+	AtRules []ComponentCSSAtRule
 }
 
 func (cssh CSSHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/css")
+	for _, ar := range cssh.AtRules {
+		_, err := w.Write([]byte(ar.Rule))
+		if err != nil && cssh.Logger != nil {
+			cssh.Logger(err)
+		}
+	}
 	for _, c := range cssh.Classes {
 		_, err := w.Write([]byte(c.Class))
 		if err != nil && cssh.Logger != nil {
@@ -375,6 +475,11 @@ func RenderCSSItems(ctx context.Context, w io.Writer, classes ...any) (err error
 				sb.WriteString(string(ccc.Class))
 				v.addClass(ccc.ID)
 			}
+		case ComponentCSSAtRule:
+			if !v.hasClassBeenRendered(ccc.Name) {
+				sb.WriteString(string(ccc.Rule))
+				v.addClass(ccc.Name)
+			}
 		case CSSClasses:
 			if err = RenderCSSItems(ctx, w, ccc...); err != nil {
 				return
@@ -4,10 +4,14 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/a-h/templ"
 	"github.com/google/go-cmp/cmp"
@@ -72,6 +76,15 @@ func TestCSSMiddleware(t *testing.T) {
 		ID:    "c2",
 		Class: ".c2{color:blue}",
 	}
+	k1 := templ.ComponentCSSAtRule{
+		Name: "k1",
+		Rule: "@keyframes k1{to{opacity:0;}}",
+	}
+	stylePageHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := templ.RenderCSSItems(r.Context(), w, c1, k1, c2); err != nil {
+			t.Fatalf("failed to render CSS: %v", err)
+		}
+	})
 
 	tests := []struct {
 		name             string
@@ -94,6 +107,20 @@ func TestCSSMiddleware(t *testing.T) {
 			expectedMIMEType: "text/css",
 			expectedBody:     ".c1{color:red}.c2{color:blue}",
 		},
+		{
+			name:             "classes can be passed as a slice",
+			input:            httptest.NewRequest("GET", "/styles/templ.css", nil),
+			handler:          templ.NewCSSMiddleware(pageHandler, []templ.ComponentCSSClass{c1, c2}...),
+			expectedMIMEType: "text/css",
+			expectedBody:     ".c1{color:red}.c2{color:blue}",
+		},
+		{
+			name:             "accessing /style/templ.css renders CSS that includes the at-rules, before the classes",
+			input:            httptest.NewRequest("GET", "/styles/templ.css", nil),
+			handler:          templ.NewCSSMiddleware(pageHandler, c1).WithAtRules(k1),
+			expectedMIMEType: "text/css",
+			expectedBody:     "@keyframes k1{to{opacity:0;}}.c1{color:red}",
+		},
 		{
 			name:             "the pageHandler is rendered",
 			input:            httptest.NewRequest("GET", "/index.html", nil),
@@ -101,6 +128,13 @@ func TestCSSMiddleware(t *testing.T) {
 			expectedMIMEType: "text/plain; charset=utf-8",
 			expectedBody:     "Hello, World!",
 		},
+		{
+			name:             "classes and at-rules in the stylesheet are not rendered in <style> elements",
+			input:            httptest.NewRequest("GET", "/index.html", nil),
+			handler:          templ.NewCSSMiddleware(stylePageHandler, c1).WithAtRules(k1),
+			expectedMIMEType: "text/html; charset=utf-8",
+			expectedBody:     `<style type="text/css">.c2{color:blue}</style>`,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -192,6 +226,34 @@ func TestRenderCSS(t *testing.T) {
 	}
 }
 
+func TestRenderCSSAtRules(t *testing.T) {
+	k1 := templ.ComponentCSSAtRule{
+		Name: "k1",
+		Rule: "@keyframes k1{to{opacity:0;}}",
+	}
+	c1 := templ.ComponentCSSClass{
+		ID:    "c1",
+		Class: ".c1{animation-name:k1;}",
+	}
+	ctx := templ.InitializeContext(context.Background())
+	b := new(bytes.Buffer)
+	if err := templ.RenderCSSItems(ctx, b, k1, c1); err != nil {
+		t.Fatalf("failed to render CSS: %v", err)
+	}
+	if diff := cmp.Diff(`<style type="text/css">@keyframes k1{to{opacity:0;}}.c1{animation-name:k1;}</style>`, b.String()); diff != "" {
+		t.Error(diff)
+	}
+
+	// At-rules are only rendered once.
+	b.Reset()
+	if err := templ.RenderCSSItems(ctx, b, templ.CSSClasses{k1}); err != nil {
+		t.Fatalf("failed to render CSS: %v", err)
+	}
+	if b.Len() != 0 {
+		t.Errorf("expected the at-rule not to be rendered again, got %q", b.String())
+	}
+}
+
 func TestClassSanitization(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -259,6 +321,14 @@ func TestClassesFunction(t *testing.T) {
 			},
 			expected: "classA classB c",
 		},
+		{
+			name: "CSS at-rules are not included in the output",
+			input: []any{
+				templ.ComponentCSSAtRule{Name: "fadeIn_1234", Rule: templ.SafeCSS("@keyframes fadeIn_1234{from{opacity:0;}}")},
+				"a",
+			},
+			expected: "a",
+		},
 		{
 			name: "optional classes can be applied with expressions",
 			input: []any{
@@ -440,3 +510,111 @@ func TestHandler(t *testing.T) {
 		})
 	}
 }
+
+type level int
+
+func (l level) String() string { return fmt.Sprintf("level %d", int(l)) }
+
+func TestToString(t *testing.T) {
+	type named string
+	tests := []struct {
+		name     string
+		convert  func() (string, error)
+		expected string
+	}{
+		{
+			name:     "strings are unchanged",
+			convert:  func() (string, error) { return templ.ToString("a") },
+			expected: "a",
+		},
+		{
+			name:     "types based on strings are converted",
+			convert:  func() (string, error) { return templ.ToString(named("b")) },
+			expected: "b",
+		},
+		{
+			name:     "integers are converted",
+			convert:  func() (string, error) { return templ.ToString(-42) },
+			expected: "-42",
+		},
+		{
+			name:     "unsigned integers are converted",
+			convert:  func() (string, error) { return templ.ToString(uint8(42)) },
+			expected: "42",
+		},
+		{
+			name:     "floats are converted",
+			convert:  func() (string, error) { return templ.ToString(1.5) },
+			expected: "1.5",
+		},
+		{
+			name:     "bools are converted",
+			convert:  func() (string, error) { return templ.ToString(true) },
+			expected: "true",
+		},
+		{
+			name:     "complex numbers are converted",
+			convert:  func() (string, error) { return templ.ToString(complex(1, 2)) },
+			expected: "(1+2i)",
+		},
+		{
+			name:     "fmt.Stringers are converted using their String method",
+			convert:  func() (string, error) { return templ.ToString(level(3)) },
+			expected: "level 3",
+		},
+		{
+			name:     "time.Duration is converted using its String method",
+			convert:  func() (string, error) { return templ.ToString(90 * time.Second) },
+			expected: "1m30s",
+		},
+		{
+			name:     "values with a nil error are converted",
+			convert:  func() (string, error) { return templ.ToString(strconv.Atoi("12")) },
+			expected: "12",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := tt.convert()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+	t.Run("errors are returned", func(t *testing.T) {
+		if _, err := templ.ToString(strconv.Atoi("a")); err == nil {
+			t.Error("expected an error, got nil")
+		}
+	})
+}
+
+func TestStringerToString(t *testing.T) {
+	t.Run("values are converted using their String method", func(t *testing.T) {
+		actual, err := templ.StringerToString(time.Date(2023, time.January, 2, 3, 4, 5, 0, time.UTC))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if diff := cmp.Diff("2023-01-02 03:04:05 +0000 UTC", actual); diff != "" {
+			t.Error(diff)
+		}
+	})
+	t.Run("errors are returned", func(t *testing.T) {
+		expected := errors.New("failed")
+		if _, err := templ.StringerToString(time.Time{}, expected); !errors.Is(err, expected) {
+			t.Errorf("expected %v, got %v", expected, err)
+		}
+	})
+	t.Run("nil values return an error", func(t *testing.T) {
+		var u *url.URL
+		if _, err := templ.StringerToString(u); err == nil {
+			t.Error("expected an error for a nil pointer, got nil")
+		}
+		if _, err := templ.StringerToString(nil); err == nil {
+			t.Error("expected an error for a nil interface, got nil")
+		}
+	})
+}
@@ -34,6 +34,7 @@ var identifierPattern = regexp.MustCompile(`^[a-zA-Z][-a-zA-Z]+$`)
 var cssPropertyNameToValueSanitizer = map[string]func(string) string{
 	"background-image":    sanitizeBackgroundImage,
 	"font-family":         sanitizeFontFamily,
+	"src":                 sanitizeFontSource,
 	"display":             sanitizeEnum,
 	"background-color":    sanitizeRegular,
 	"background-position": sanitizeRegular,
@@ -82,7 +83,25 @@ func urlIsSafe(s string) bool {
 	return true
 }
 
-var genericFontFamilyName = regexp.MustCompile(`^[a-zA-Z][- a-zA-Z]+$`)
+// fontSourcePattern matches a @font-face src of the form url("...") format("...") or local("...").
+var fontSourcePattern = regexp.MustCompile(`^(?:url\("([^"\\()]*)"\)(?:\s+format\("[-a-zA-Z0-9]+"\))?|local\("[^"\\()]*"\))$`)
+
+func sanitizeFontSource(v string) string {
+	for _, src := range strings.Split(v, ",") {
+		m := fontSourcePattern.FindStringSubmatch(strings.TrimSpace(src))
+		if m == nil {
+			return InnocuousPropertyValue
+		}
+		if m[1] != "" && !urlIsSafe(m[1]) {
+			return InnocuousPropertyValue
+		}
+	}
+	return v
+}
+
+// genericFontFamilyName matches unquoted font family names, including the names created by
+// css fontface templates, e.g. brand_1a2b.
+var genericFontFamilyName = regexp.MustCompile(`^[a-zA-Z][- a-zA-Z0-9_]+$`)
 
 func sanitizeFontFamily(s string) string {
 	for _, f := range strings.Split(s, ",") {
@@ -80,6 +80,34 @@ func TestSanitizeCSS(t *testing.T) {
 			inputValue:       `"中易宋体", monospaced`,
 			expectedValue:    `"中易宋体", monospaced`,
 		},
+		{
+			name:             "font-family generated names are allowed",
+			inputProperty:    "font-family",
+			expectedProperty: "font-family",
+			inputValue:       `brand_1a2b, sans-serif`,
+			expectedValue:    `brand_1a2b, sans-serif`,
+		},
+		{
+			name:             "src urls, formats and local fonts are allowed",
+			inputProperty:    "src",
+			expectedProperty: "src",
+			inputValue:       `local("Brand"), url("/fonts/brand.woff2") format("woff2")`,
+			expectedValue:    `local("Brand"), url("/fonts/brand.woff2") format("woff2")`,
+		},
+		{
+			name:             "src urls must be safe",
+			inputProperty:    "src",
+			expectedProperty: "src",
+			inputValue:       `url("javascript:void")`,
+			expectedValue:    InnocuousPropertyValue,
+		},
+		{
+			name:             "src values must be urls or local fonts",
+			inputProperty:    "src",
+			expectedProperty: "src",
+			inputValue:       `expression(alert(1))`,
+			expectedValue:    InnocuousPropertyValue,
+		},
 		{
 			name:             "font-family quoted values must be terminated",
 			inputProperty:    "font-family",
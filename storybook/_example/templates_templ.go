@@ -46,7 +46,11 @@ func headerTemplate(name string) templ.Component {
 			return err
 		}
 		// StringExpression
-		var var_2 string = name
+		var var_2 string
+		var_2, err = templ.ToString(name)
+		if err != nil {
+			return err
+		}
 		_, err = templBuffer.WriteString(templ.EscapeString(var_2))
 		if err != nil {
 			return err
@@ -105,7 +109,11 @@ func footerTemplate() templ.Component {
 			return err
 		}
 		// StringExpression
-		var var_5 string = fmt.Sprintf("%d", time.Now().Year())
+		var var_5 string
+		var_5, err = templ.ToString(fmt.Sprintf("%d", time.Now().Year()))
+		if err != nil {
+			return err
+		}
 		_, err = templBuffer.WriteString(templ.EscapeString(var_5))
 		if err != nil {
 			return err